@@ -2,10 +2,13 @@ package fluvio
 
 import (
 	"context"
+	"sort"
 
 	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
 	"github.com/iwen-conf/fluvio_grpc_client/application/services"
+	"github.com/iwen-conf/fluvio_grpc_client/domain/entities"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/metrics"
 	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
 )
 
@@ -13,6 +16,7 @@ import (
 type AdminManager struct {
 	appService *services.FluvioApplicationService
 	logger     logging.Logger
+	metrics    metrics.Sink
 	connected  *bool
 }
 
@@ -32,16 +36,63 @@ type BrokerInfo struct {
 }
 
 // ConsumerGroupInfo 消费者组信息
+// Members和Topics仅在DescribeConsumerGroup时填充
 type ConsumerGroupInfo struct {
-	GroupID string `json:"group_id"`
-	State   string `json:"state"`
+	GroupID string                     `json:"group_id"`
+	State   string                     `json:"state"`
+	Members []*ConsumerGroupMemberInfo `json:"members,omitempty"`
+	Topics  []*ConsumerGroupTopicInfo  `json:"topics,omitempty"`
+}
+
+// ConsumerGroupMemberInfo 消费者组成员信息
+type ConsumerGroupMemberInfo struct {
+	MemberID   string `json:"member_id"`
+	ClientID   string `json:"client_id"`
+	ClientHost string `json:"client_host"`
+}
+
+// ConsumerGroupTopicInfo 消费者组在某个主题上的消费进度
+type ConsumerGroupTopicInfo struct {
+	Topic      string                        `json:"topic"`
+	Partitions []*ConsumerGroupPartitionInfo `json:"partitions"`
+}
+
+// ConsumerGroupPartitionInfo 消费者组在某个分区上的已提交偏移量
+type ConsumerGroupPartitionInfo struct {
+	Partition       int32 `json:"partition"`
+	CommittedOffset int64 `json:"committed_offset"`
+}
+
+// ConsumerGroupOffset 消费者组在指定分区上的偏移量
+type ConsumerGroupOffset struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+// CommittedOffset 获取指定分区的已提交偏移量
+func (g *ConsumerGroupInfo) CommittedOffset(topic string, partition int32) (int64, bool) {
+	for _, t := range g.Topics {
+		if t.Topic != topic {
+			continue
+		}
+		for _, p := range t.Partitions {
+			if p.Partition == partition {
+				return p.CommittedOffset, true
+			}
+		}
+	}
+	return 0, false
 }
 
 // SmartModuleInfo SmartModule信息
 type SmartModuleInfo struct {
-	Name        string `json:"name"`
-	Version     string `json:"version"`
-	Description string `json:"description"`
+	Name        string                  `json:"name"`
+	Version     string                  `json:"version"`
+	Description string                  `json:"description"`
+	InputKind   SmartModuleKind         `json:"input_kind,omitempty"`
+	OutputKind  SmartModuleKind         `json:"output_kind,omitempty"`
+	Parameters  []*SmartModuleParameter `json:"parameters,omitempty"`
 }
 
 // ClusterInfo 获取集群信息
@@ -142,6 +193,123 @@ func (a *AdminManager) ConsumerGroups(ctx context.Context) ([]*ConsumerGroupInfo
 	return groups, nil
 }
 
+// DescribeConsumerGroup 获取消费者组详情，包括成员以及各主题分区的已提交偏移量
+func (a *AdminManager) DescribeConsumerGroup(ctx context.Context, groupID string) (*ConsumerGroupInfo, error) {
+	if !*a.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if groupID == "" {
+		return nil, errors.New(errors.ErrInvalidArgument, "group id cannot be empty")
+	}
+
+	a.logger.Debug("Describing consumer group", logging.Field{Key: "group_id", Value: groupID})
+
+	group, err := a.appService.GetConsumerGroup(ctx, groupID)
+	if err != nil {
+		a.logger.Error("Failed to describe consumer group", logging.Field{Key: "error", Value: err})
+		return nil, errors.Wrap(errors.ErrOperation, "failed to describe consumer group", err)
+	}
+
+	info := consumerGroupEntityToInfo(group)
+
+	a.logger.Info("Consumer group described successfully",
+		logging.Field{Key: "group_id", Value: groupID},
+		logging.Field{Key: "topics", Value: len(info.Topics)})
+	return info, nil
+}
+
+// CreateConsumerGroup 创建消费者组，并在指定分区上提交初始偏移量
+// 服务端在首次提交偏移量时创建消费组，因此至少需要一个初始偏移量
+func (a *AdminManager) CreateConsumerGroup(ctx context.Context, groupID string, offsets []*ConsumerGroupOffset) error {
+	if !*a.connected {
+		return errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if groupID == "" {
+		return errors.New(errors.ErrInvalidArgument, "group id cannot be empty")
+	}
+	if len(offsets) == 0 {
+		return errors.New(errors.ErrInvalidArgument, "at least one initial offset is required")
+	}
+
+	a.logger.Debug("Creating consumer group", logging.Field{Key: "group_id", Value: groupID})
+
+	group := entities.NewConsumerGroup(groupID)
+	for _, offset := range offsets {
+		group.UpdateOffset(offset.Topic, offset.Partition, offset.Offset)
+	}
+
+	if err := a.appService.CreateConsumerGroup(ctx, group); err != nil {
+		a.logger.Error("Failed to create consumer group", logging.Field{Key: "error", Value: err})
+		return errors.Wrap(errors.ErrOperation, "failed to create consumer group", err)
+	}
+
+	a.logger.Info("Consumer group created successfully", logging.Field{Key: "group_id", Value: groupID})
+	return nil
+}
+
+// DeleteConsumerGroup 删除消费者组
+func (a *AdminManager) DeleteConsumerGroup(ctx context.Context, groupID string) error {
+	if !*a.connected {
+		return errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if groupID == "" {
+		return errors.New(errors.ErrInvalidArgument, "group id cannot be empty")
+	}
+
+	a.logger.Debug("Deleting consumer group", logging.Field{Key: "group_id", Value: groupID})
+
+	if err := a.appService.DeleteConsumerGroup(ctx, groupID); err != nil {
+		a.logger.Error("Failed to delete consumer group", logging.Field{Key: "error", Value: err})
+		return errors.Wrap(errors.ErrOperation, "failed to delete consumer group", err)
+	}
+
+	a.logger.Info("Consumer group deleted successfully", logging.Field{Key: "group_id", Value: groupID})
+	return nil
+}
+
+// consumerGroupEntityToInfo 将消费者组实体转换为对外的信息结构，偏移量按主题和分区排序
+func consumerGroupEntityToInfo(group *entities.ConsumerGroup) *ConsumerGroupInfo {
+	info := &ConsumerGroupInfo{
+		GroupID: group.GroupID,
+		State:   group.State,
+		Members: make([]*ConsumerGroupMemberInfo, 0, len(group.Members)),
+		Topics:  make([]*ConsumerGroupTopicInfo, 0),
+	}
+
+	for _, member := range group.Members {
+		info.Members = append(info.Members, &ConsumerGroupMemberInfo{
+			MemberID:   member.MemberID,
+			ClientID:   member.ClientID,
+			ClientHost: member.ClientHost,
+		})
+	}
+
+	byTopic := make(map[string]*ConsumerGroupTopicInfo)
+	for _, offset := range group.Offsets {
+		topic, ok := byTopic[offset.Topic]
+		if !ok {
+			topic = &ConsumerGroupTopicInfo{Topic: offset.Topic}
+			byTopic[offset.Topic] = topic
+			info.Topics = append(info.Topics, topic)
+		}
+		topic.Partitions = append(topic.Partitions, &ConsumerGroupPartitionInfo{
+			Partition:       offset.Partition,
+			CommittedOffset: offset.Offset,
+		})
+	}
+
+	sort.Slice(info.Topics, func(i, j int) bool { return info.Topics[i].Topic < info.Topics[j].Topic })
+	for _, topic := range info.Topics {
+		partitions := topic.Partitions
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i].Partition < partitions[j].Partition })
+	}
+
+	return info
+}
+
 // SmartModules 获取SmartModule管理器
 func (a *AdminManager) SmartModules() *SmartModuleManager {
 	return &SmartModuleManager{
@@ -180,10 +348,14 @@ func (s *SmartModuleManager) List(ctx context.Context) ([]*SmartModuleInfo, erro
 
 	var modules []*SmartModuleInfo
 	for _, module := range resp.Modules {
+		spec := smartModuleSpecFromDTO(module)
 		moduleInfo := &SmartModuleInfo{
-			Name:        module.Name,
-			Version:     module.Version,
-			Description: module.Description,
+			Name:        spec.Name,
+			Version:     spec.Version,
+			Description: spec.Description,
+			InputKind:   spec.InputKind,
+			OutputKind:  spec.OutputKind,
+			Parameters:  spec.Parameters,
 		}
 		modules = append(modules, moduleInfo)
 	}
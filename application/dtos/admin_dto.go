@@ -64,9 +64,17 @@ type DescribeConsumerGroupResponse struct {
 
 // ConsumerGroupDTO 消费者组信息
 type ConsumerGroupDTO struct {
-	GroupID string                     `json:"group_id"`
-	State   string                     `json:"state"`
+	GroupID string                    `json:"group_id"`
+	State   string                    `json:"state"`
 	Members []*ConsumerGroupMemberDTO `json:"members,omitempty"`
+	Offsets []*ConsumerGroupOffsetDTO `json:"offsets,omitempty"`
+}
+
+// ConsumerGroupOffsetDTO 消费者组在某个分区上的已提交偏移量
+type ConsumerGroupOffsetDTO struct {
+	Topic           string `json:"topic"`
+	Partition       int32  `json:"partition"`
+	CommittedOffset int64  `json:"committed_offset"`
 }
 
 // ConsumerGroupMemberDTO 消费者组成员信息
@@ -89,8 +97,9 @@ type ListSmartModulesResponse struct {
 
 // CreateSmartModuleRequest 创建SmartModule请求
 type CreateSmartModuleRequest struct {
-	Name     string `json:"name"`
-	WasmCode []byte `json:"wasm_code"`
+	Name     string          `json:"name"`
+	Spec     *SmartModuleDTO `json:"spec,omitempty"` // 为空时使用默认规格
+	WasmCode []byte          `json:"wasm_code"`
 }
 
 // CreateSmartModuleResponse 创建SmartModule响应
@@ -110,6 +119,19 @@ type DeleteSmartModuleResponse struct {
 	Error   string `json:"error,omitempty"`
 }
 
+// UpdateSmartModuleRequest 更新SmartModule请求
+type UpdateSmartModuleRequest struct {
+	Name     string          `json:"name"`
+	Spec     *SmartModuleDTO `json:"spec,omitempty"`      // 可选：更新规格
+	WasmCode []byte          `json:"wasm_code,omitempty"` // 可选：更新Wasm代码
+}
+
+// UpdateSmartModuleResponse 更新SmartModule响应
+type UpdateSmartModuleResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
 // DescribeSmartModuleRequest 描述SmartModule请求
 type DescribeSmartModuleRequest struct {
 	Name string `json:"name"`
@@ -123,9 +145,173 @@ type DescribeSmartModuleResponse struct {
 
 // SmartModuleDTO SmartModule信息
 type SmartModuleDTO struct {
-	Name        string    `json:"name"`
-	Version     string    `json:"version"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-}
\ No newline at end of file
+	Name        string                     `json:"name"`
+	Version     string                     `json:"version"`
+	Description string                     `json:"description"`
+	InputKind   string                     `json:"input_kind,omitempty"`  // stream 或 table
+	OutputKind  string                     `json:"output_kind,omitempty"` // stream 或 table
+	Parameters  []*SmartModuleParameterDTO `json:"parameters,omitempty"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+}
+
+// SmartModuleParameterDTO SmartModule参数声明
+type SmartModuleParameterDTO struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Optional    bool   `json:"optional"`
+}
+
+// 批量操作相关DTO
+
+// BulkDeleteRequest 批量删除请求
+type BulkDeleteRequest struct {
+	Topics         []string `json:"topics,omitempty"`
+	ConsumerGroups []string `json:"consumer_groups,omitempty"`
+	SmartModules   []string `json:"smart_modules,omitempty"`
+	Force          bool     `json:"force,omitempty"`
+}
+
+// BulkDeleteResponse 批量删除响应
+type BulkDeleteResponse struct {
+	Results           []*BulkDeleteResultDTO `json:"results"`
+	TotalRequested    int32                  `json:"total_requested"`
+	SuccessfulDeletes int32                  `json:"successful_deletes"`
+	FailedDeletes     int32                  `json:"failed_deletes"`
+	Error             string                 `json:"error,omitempty"`
+}
+
+// BulkDeleteResultDTO 单个资源的删除结果
+type BulkDeleteResultDTO struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// 指标相关DTO
+
+// GetMetricsRequest 获取集群指标请求
+type GetMetricsRequest struct {
+	MetricNames []string          `json:"metric_names,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// GetMetricsResponse 获取集群指标响应
+type GetMetricsResponse struct {
+	Metrics []*MetricDTO `json:"metrics"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// MetricDTO 指标样本
+type MetricDTO struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Value     float64           `json:"value"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// 存储管理相关DTO
+
+// GetStorageStatusRequest 获取存储状态请求
+type GetStorageStatusRequest struct {
+	IncludeDetails bool `json:"include_details"`
+}
+
+// GetStorageStatusResponse 获取存储状态响应
+type GetStorageStatusResponse struct {
+	PersistenceEnabled bool             `json:"persistence_enabled"`
+	Stats              *StorageStatsDTO `json:"stats,omitempty"`
+	CheckedAt          time.Time        `json:"checked_at"`
+	Error              string           `json:"error,omitempty"`
+}
+
+// StorageStatsDTO 存储统计信息
+type StorageStatsDTO struct {
+	StorageType      string                     `json:"storage_type"`
+	ConsumerGroups   uint64                     `json:"consumer_groups"`
+	ConsumerOffsets  uint64                     `json:"consumer_offsets"`
+	SmartModules     uint64                     `json:"smart_modules"`
+	ConnectionStatus string                     `json:"connection_status"`
+	ConnectionStats  *StorageConnectionStatsDTO `json:"connection_stats,omitempty"`
+	DatabaseInfo     *StorageDatabaseInfoDTO    `json:"database_info,omitempty"`
+}
+
+// StorageConnectionStatsDTO 存储连接统计
+type StorageConnectionStatsDTO struct {
+	CurrentConnections      uint32 `json:"current_connections"`
+	AvailableConnections    uint32 `json:"available_connections"`
+	TotalCreatedConnections uint32 `json:"total_created_connections"`
+}
+
+// StorageDatabaseInfoDTO 存储数据库信息
+type StorageDatabaseInfoDTO struct {
+	Name        string `json:"name"`
+	Collections uint32 `json:"collections"`
+	DataSize    uint64 `json:"data_size"`
+	StorageSize uint64 `json:"storage_size"`
+	Indexes     uint32 `json:"indexes"`
+	IndexSize   uint64 `json:"index_size"`
+}
+
+// GetStorageMetricsRequest 获取存储指标请求
+type GetStorageMetricsRequest struct {
+	IncludeHistory bool   `json:"include_history"`
+	HistoryLimit   uint32 `json:"history_limit,omitempty"`
+}
+
+// GetStorageMetricsResponse 获取存储指标响应
+type GetStorageMetricsResponse struct {
+	Current     *StorageMetricsDTO     `json:"current,omitempty"`
+	History     []*StorageMetricsDTO   `json:"history,omitempty"`
+	Health      *StorageHealthCheckDTO `json:"health,omitempty"`
+	Alerts      []string               `json:"alerts,omitempty"`
+	CollectedAt time.Time              `json:"collected_at"`
+	Error       string                 `json:"error,omitempty"`
+}
+
+// StorageMetricsDTO 存储性能指标
+type StorageMetricsDTO struct {
+	StorageType         string    `json:"storage_type"`
+	ResponseTimeMs      uint64    `json:"response_time_ms"`
+	OperationsPerSecond float64   `json:"operations_per_second"`
+	ErrorRate           float64   `json:"error_rate"`
+	ConnectionPoolUsage float64   `json:"connection_pool_usage"`
+	MemoryUsageMB       uint64    `json:"memory_usage_mb"`
+	DiskUsageMB         uint64    `json:"disk_usage_mb"`
+	LastUpdated         time.Time `json:"last_updated"`
+}
+
+// StorageHealthCheckDTO 存储健康检查结果
+type StorageHealthCheckDTO struct {
+	Status         string    `json:"status"`
+	ResponseTimeMs uint64    `json:"response_time_ms"`
+	ErrorMessage   string    `json:"error_message,omitempty"`
+	CheckedAt      time.Time `json:"checked_at"`
+}
+
+// MigrateStorageRequest 存储迁移请求
+type MigrateStorageRequest struct {
+	SourceType      string `json:"source_type"`
+	TargetType      string `json:"target_type"`
+	VerifyMigration bool   `json:"verify_migration"`
+	ForceMigration  bool   `json:"force_migration"`
+}
+
+// MigrateStorageResponse 存储迁移响应
+type MigrateStorageResponse struct {
+	Success            bool               `json:"success"`
+	Stats              *MigrationStatsDTO `json:"stats,omitempty"`
+	VerificationPassed bool               `json:"verification_passed"`
+	CompletedAt        time.Time          `json:"completed_at"`
+	Error              string             `json:"error,omitempty"`
+}
+
+// MigrationStatsDTO 存储迁移统计
+type MigrationStatsDTO struct {
+	ConsumerGroupsMigrated  uint64   `json:"consumer_groups_migrated"`
+	ConsumerOffsetsMigrated uint64   `json:"consumer_offsets_migrated"`
+	SmartModulesMigrated    uint64   `json:"smart_modules_migrated"`
+	TotalMigrated           uint64   `json:"total_migrated"`
+	Errors                  []string `json:"errors,omitempty"`
+}
@@ -11,6 +11,7 @@ type ProduceMessageRequest struct {
 	Value     string            `json:"value"`
 	MessageID string            `json:"message_id,omitempty"`
 	Headers   map[string]string `json:"headers,omitempty"`
+	Timestamp time.Time         `json:"timestamp,omitempty"` // 为零值时使用当前时间
 }
 
 // ProduceMessageResponse 生产消息响应DTO
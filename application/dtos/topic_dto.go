@@ -38,6 +38,7 @@ type PartitionInfoDTO struct {
 	PartitionID    int32   `json:"partition_id"`
 	LeaderID       int32   `json:"leader_id"`
 	ReplicaIDs     []int32 `json:"replica_ids"`
+	ISRIDs         []int32 `json:"isr_ids"`
 	HighWatermark  int64   `json:"high_watermark"`
 	LowWatermark   int64   `json:"low_watermark"`
 	MessageCount   int64   `json:"message_count"`
@@ -61,15 +62,16 @@ type TopicDetailResponse struct {
 
 // TopicStatsRequest 主题统计请求DTO
 type TopicStatsRequest struct {
-	Topic             string `json:"topic"`
+	Topic             string `json:"topic"` // 为空则获取所有主题统计
 	IncludePartitions bool   `json:"include_partitions"`
 }
 
 // TopicStatsResponse 主题统计响应DTO
 type TopicStatsResponse struct {
-	Topics  []*TopicStatsDTO `json:"topics"`
-	Success bool             `json:"success"`
-	Error   string           `json:"error,omitempty"`
+	Topics      []*TopicStatsDTO `json:"topics"`
+	CollectedAt time.Time        `json:"collected_at"`
+	Success     bool             `json:"success"`
+	Error       string           `json:"error,omitempty"`
 }
 
 // TopicStatsDTO 主题统计DTO
@@ -78,16 +80,20 @@ type TopicStatsDTO struct {
 	TotalMessageCount int64                `json:"total_message_count"`
 	TotalSizeBytes    int64                `json:"total_size_bytes"`
 	PartitionCount    int32                `json:"partition_count"`
+	ReplicationFactor int32                `json:"replication_factor"`
 	Partitions        []*PartitionStatsDTO `json:"partitions,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	LastUpdated       time.Time            `json:"last_updated"`
 }
 
 // PartitionStatsDTO 分区统计DTO
 type PartitionStatsDTO struct {
-	PartitionID    int32 `json:"partition_id"`
-	MessageCount   int64 `json:"message_count"`
-	TotalSizeBytes int64 `json:"total_size_bytes"`
-	HighWatermark  int64 `json:"high_watermark"`
-	LowWatermark   int64 `json:"low_watermark"`
+	PartitionID    int32     `json:"partition_id"`
+	MessageCount   int64     `json:"message_count"`
+	TotalSizeBytes int64     `json:"total_size_bytes"`
+	HighWatermark  int64     `json:"high_watermark"`
+	LowWatermark   int64     `json:"low_watermark"`
+	LastUpdated    time.Time `json:"last_updated"`
 }
 
 // DeleteTopicRequest 删除主题请求DTO
@@ -15,6 +15,7 @@ type FluvioApplicationService struct {
 	messageRepo repositories.MessageRepository
 	topicRepo   repositories.TopicRepository
 	adminRepo   repositories.AdminRepository
+	groupRepo   repositories.ConsumerGroupRepository
 	logger      logging.Logger
 }
 
@@ -33,6 +34,19 @@ func NewFluvioApplicationService(
 	}
 }
 
+// SetConsumerGroupRepository 设置消费者组仓储，未设置时消费者组实体相关方法返回错误
+func (s *FluvioApplicationService) SetConsumerGroupRepository(groupRepo repositories.ConsumerGroupRepository) {
+	s.groupRepo = groupRepo
+}
+
+// consumerGroups 获取消费者组仓储
+func (s *FluvioApplicationService) consumerGroups() (repositories.ConsumerGroupRepository, error) {
+	if s.groupRepo == nil {
+		return nil, fmt.Errorf("consumer group repository not configured")
+	}
+	return s.groupRepo, nil
+}
+
 // ProduceMessage 生产消息
 func (s *FluvioApplicationService) ProduceMessage(ctx context.Context, req *dtos.ProduceMessageRequest) (*dtos.ProduceMessageResponse, error) {
 	// 基本验证
@@ -55,6 +69,10 @@ func (s *FluvioApplicationService) ProduceMessage(ctx context.Context, req *dtos
 		message.WithHeaders(req.Headers)
 	}
 
+	if !req.Timestamp.IsZero() {
+		message.Timestamp = req.Timestamp
+	}
+
 	// 直接调用仓储层，让gRPC处理业务逻辑
 	if err := s.messageRepo.Produce(ctx, message); err != nil {
 		return &dtos.ProduceMessageResponse{
@@ -72,6 +90,59 @@ func (s *FluvioApplicationService) ProduceMessage(ctx context.Context, req *dtos
 	}, nil
 }
 
+// ProduceBatch 通过BatchProduce批量生产消息，Results与请求中的消息一一对应
+// 所有消息必须属于同一个主题
+func (s *FluvioApplicationService) ProduceBatch(ctx context.Context, req *dtos.ProduceBatchRequest) (*dtos.ProduceBatchResponse, error) {
+	if req == nil || len(req.Messages) == 0 {
+		return &dtos.ProduceBatchResponse{}, nil
+	}
+
+	messages := make([]*entities.Message, len(req.Messages))
+	for i, msgReq := range req.Messages {
+		if msgReq.Topic != req.Messages[0].Topic {
+			return nil, fmt.Errorf("all messages in a batch must belong to the same topic")
+		}
+
+		message := entities.NewMessage(msgReq.Key, msgReq.Value)
+		message.Topic = msgReq.Topic
+		if msgReq.MessageID != "" {
+			message.WithMessageID(msgReq.MessageID)
+		}
+		if msgReq.Headers != nil {
+			message.WithHeaders(msgReq.Headers)
+		}
+		if !msgReq.Timestamp.IsZero() {
+			message.Timestamp = msgReq.Timestamp
+		}
+		messages[i] = message
+	}
+
+	results, err := s.messageRepo.ProduceBatchResults(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dtos.ProduceBatchResponse{
+		Results:       make([]*dtos.ProduceMessageResponse, len(messages)),
+		TotalMessages: len(messages),
+	}
+	for i, message := range messages {
+		result := &dtos.ProduceMessageResponse{
+			MessageID: message.MessageID,
+			Topic:     message.Topic,
+			Success:   results[i] == nil,
+		}
+		if results[i] != nil {
+			result.Error = results[i].Error()
+			resp.FailureCount++
+		} else {
+			resp.SuccessCount++
+		}
+		resp.Results[i] = result
+	}
+	return resp, nil
+}
+
 // ConsumeMessage 消费消息
 func (s *FluvioApplicationService) ConsumeMessage(ctx context.Context, req *dtos.ConsumeMessageRequest) (*dtos.ConsumeMessageResponse, error) {
 	s.logger.Debug("Consuming messages", logging.Field{Key: "topic", Value: req.Topic})
@@ -188,6 +259,16 @@ func (s *FluvioApplicationService) DescribeTopic(ctx context.Context, req *dtos.
 	return s.topicRepo.DescribeTopic(ctx, req)
 }
 
+// GetTopicStats 获取主题统计信息
+func (s *FluvioApplicationService) GetTopicStats(ctx context.Context, req *dtos.TopicStatsRequest) (*dtos.TopicStatsResponse, error) {
+	return s.topicRepo.GetTopicStats(ctx, req)
+}
+
+// GetPartitionStats 获取分区统计信息
+func (s *FluvioApplicationService) GetPartitionStats(ctx context.Context, topic string, partition int32) (*repositories.PartitionStats, error) {
+	return s.topicRepo.GetPartitionStats(ctx, topic, partition)
+}
+
 // 管理功能
 
 // DescribeCluster 描述集群
@@ -210,6 +291,33 @@ func (s *FluvioApplicationService) DescribeConsumerGroup(ctx context.Context, re
 	return s.adminRepo.DescribeConsumerGroup(ctx, req)
 }
 
+// GetConsumerGroup 获取消费者组详情实体
+func (s *FluvioApplicationService) GetConsumerGroup(ctx context.Context, groupID string) (*entities.ConsumerGroup, error) {
+	groups, err := s.consumerGroups()
+	if err != nil {
+		return nil, err
+	}
+	return groups.GetByID(ctx, groupID)
+}
+
+// CreateConsumerGroup 创建消费者组
+func (s *FluvioApplicationService) CreateConsumerGroup(ctx context.Context, group *entities.ConsumerGroup) error {
+	groups, err := s.consumerGroups()
+	if err != nil {
+		return err
+	}
+	return groups.Create(ctx, group)
+}
+
+// DeleteConsumerGroup 删除消费者组
+func (s *FluvioApplicationService) DeleteConsumerGroup(ctx context.Context, groupID string) error {
+	groups, err := s.consumerGroups()
+	if err != nil {
+		return err
+	}
+	return groups.Delete(ctx, groupID)
+}
+
 // ListSmartModules 列出SmartModule
 func (s *FluvioApplicationService) ListSmartModules(ctx context.Context, req *dtos.ListSmartModulesRequest) (*dtos.ListSmartModulesResponse, error) {
 	return s.adminRepo.ListSmartModules(ctx, req)
@@ -229,3 +337,33 @@ func (s *FluvioApplicationService) DeleteSmartModule(ctx context.Context, req *d
 func (s *FluvioApplicationService) DescribeSmartModule(ctx context.Context, req *dtos.DescribeSmartModuleRequest) (*dtos.DescribeSmartModuleResponse, error) {
 	return s.adminRepo.DescribeSmartModule(ctx, req)
 }
+
+// BulkDelete 批量删除资源
+func (s *FluvioApplicationService) BulkDelete(ctx context.Context, req *dtos.BulkDeleteRequest) (*dtos.BulkDeleteResponse, error) {
+	return s.adminRepo.BulkDelete(ctx, req)
+}
+
+// UpdateSmartModule 更新SmartModule
+func (s *FluvioApplicationService) UpdateSmartModule(ctx context.Context, req *dtos.UpdateSmartModuleRequest) (*dtos.UpdateSmartModuleResponse, error) {
+	return s.adminRepo.UpdateSmartModule(ctx, req)
+}
+
+// GetMetrics 获取集群指标
+func (s *FluvioApplicationService) GetMetrics(ctx context.Context, req *dtos.GetMetricsRequest) (*dtos.GetMetricsResponse, error) {
+	return s.adminRepo.GetMetrics(ctx, req)
+}
+
+// GetStorageStatus 获取存储状态
+func (s *FluvioApplicationService) GetStorageStatus(ctx context.Context, req *dtos.GetStorageStatusRequest) (*dtos.GetStorageStatusResponse, error) {
+	return s.adminRepo.GetStorageStatus(ctx, req)
+}
+
+// GetStorageMetrics 获取存储指标
+func (s *FluvioApplicationService) GetStorageMetrics(ctx context.Context, req *dtos.GetStorageMetricsRequest) (*dtos.GetStorageMetricsResponse, error) {
+	return s.adminRepo.GetStorageMetrics(ctx, req)
+}
+
+// MigrateStorage 迁移存储
+func (s *FluvioApplicationService) MigrateStorage(ctx context.Context, req *dtos.MigrateStorageRequest) (*dtos.MigrateStorageResponse, error) {
+	return s.adminRepo.MigrateStorage(ctx, req)
+}
@@ -0,0 +1,51 @@
+package fluvio
+
+import (
+	"context"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/config"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/grpc"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+
+	grpclib "google.golang.org/grpc"
+)
+
+// Token 访问令牌
+type Token = grpc.Token
+
+// TokenSource 令牌来源，WithTokenSource会在其外层加缓存，实现只需在被调用时返回新令牌
+type TokenSource = grpc.TokenSource
+
+// TokenSourceFunc 函数形式的TokenSource
+type TokenSourceFunc = grpc.TokenSourceFunc
+
+// StaticTokenSource 返回固定令牌的TokenSource
+func StaticTokenSource(accessToken string) TokenSource {
+	token := &Token{AccessToken: accessToken}
+	return TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		return token, nil
+	})
+}
+
+// configureAuth 根据WithTokenSource、WithAPIKey配置PerRPCCredentials和认证拦截器
+func configureAuth(cfg *config.Config, connManager *grpc.ConnectionManager, logger logging.Logger) {
+	source, hasToken := cfg.Extensions["token_source"].(TokenSource)
+	apiKey, hasKey := cfg.Extensions["api_key"].(string)
+	if !hasToken && !hasKey {
+		return
+	}
+
+	if !cfg.Connection.TLSEnabled {
+		logger.Warn("Credentials are sent over a plaintext connection; enable TLS in production")
+	}
+
+	var cached *grpc.CachingTokenSource
+	if hasToken {
+		cached = grpc.NewCachingTokenSource(source, grpc.DefaultTokenRefreshBefore, logger)
+		connManager.AddDialOptions(grpclib.WithPerRPCCredentials(grpc.NewTokenCredentials(cached, cfg.Connection.TLSEnabled)))
+	}
+	if hasKey {
+		connManager.AddDialOptions(grpclib.WithPerRPCCredentials(grpc.NewAPIKeyCredentials(apiKey, cfg.Connection.TLSEnabled)))
+	}
+	connManager.AddInterceptors(grpc.NewAuthInterceptors(cached, logger))
+}
@@ -0,0 +1,524 @@
+package fluvio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// BackupManager 主题数据备份与恢复
+type BackupManager struct {
+	topics   *TopicManager
+	consumer *Consumer
+	producer *Producer
+	logger   logging.Logger
+}
+
+// Backup 获取备份管理器
+func (c *Client) Backup() *BackupManager {
+	return &BackupManager{
+		topics:   c.Topics(),
+		consumer: c.Consumer(),
+		producer: c.Producer(),
+		logger:   c.logger,
+	}
+}
+
+// ExportOptions 导出选项
+type ExportOptions struct {
+	Format     BackupFormat `json:"format,omitempty"`     // 默认ndjson
+	Partitions []int32      `json:"partitions,omitempty"` // 为空表示所有分区
+	BatchSize  int          `json:"batch_size,omitempty"` // 每次Consume的消息数，默认500
+}
+
+// PartitionBackupInfo 单个分区的备份范围
+type PartitionBackupInfo struct {
+	Partition   int32 `json:"partition"`
+	FirstOffset int64 `json:"first_offset"` // 导出开始时的最早偏移量
+	EndOffset   int64 `json:"end_offset"`   // 导出开始时的最新偏移量（不包含）
+	Count       int64 `json:"count"`
+}
+
+// ExportResult 导出结果
+type ExportResult struct {
+	Topic      string                 `json:"topic"`
+	Format     BackupFormat           `json:"format"`
+	Partitions []*PartitionBackupInfo `json:"partitions"`
+	Total      int64                  `json:"total"`
+	Checksum   string                 `json:"checksum"` // 所有消息的SHA-256，与格式无关
+	Duration   time.Duration          `json:"duration"`
+}
+
+// Export 将主题的每个分区从最早到导出开始时的最新偏移量写入w
+// 消息按分区、偏移量顺序写入；导出期间新写入的消息不包含在备份中
+func (b *BackupManager) Export(ctx context.Context, topic string, w io.Writer, opts *ExportOptions) (*ExportResult, error) {
+	if topic == "" {
+		return nil, errors.New(errors.ErrInvalidArgument, "topic name cannot be empty")
+	}
+	o := ExportOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Format == "" {
+		o.Format = BackupNDJSON
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 500
+	}
+
+	partitions := o.Partitions
+	if len(partitions) == 0 {
+		info, err := b.topics.Info(ctx, topic)
+		if err != nil {
+			return nil, err
+		}
+		for p := int32(0); p < info.Partitions; p++ {
+			partitions = append(partitions, p)
+		}
+	}
+	partitions = append([]int32(nil), partitions...)
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	writer, err := newArchiveWriter(w, o.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	b.logger.Info("Exporting topic",
+		logging.Field{Key: "topic", Value: topic},
+		logging.Field{Key: "format", Value: o.Format},
+		logging.Field{Key: "partitions", Value: len(partitions)})
+
+	start := time.Now()
+	if err := writer.writeHeader(&archiveHeader{Topic: topic, Partitions: partitions, CreatedAt: start.UTC()}); err != nil {
+		return nil, errors.Wrap(errors.ErrOperation, "failed to write backup header", err)
+	}
+
+	result := &ExportResult{Topic: topic, Format: o.Format}
+	for _, partition := range partitions {
+		info, err := b.exportPartition(ctx, topic, partition, o.BatchSize, writer)
+		if err != nil {
+			return nil, err
+		}
+		result.Partitions = append(result.Partitions, info)
+		result.Total += info.Count
+	}
+
+	footer, err := writer.writeFooter(result.Partitions)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrOperation, "failed to write backup footer", err)
+	}
+	result.Checksum = footer.Checksum
+	result.Duration = time.Since(start)
+
+	b.logger.Info("Topic exported",
+		logging.Field{Key: "topic", Value: topic},
+		logging.Field{Key: "messages", Value: result.Total},
+		logging.Field{Key: "duration", Value: result.Duration})
+	return result, nil
+}
+
+// exportPartition 导出单个分区 [earliest, latest) 范围内的消息
+func (b *BackupManager) exportPartition(ctx context.Context, topic string, partition int32, batchSize int, writer *archiveWriter) (*PartitionBackupInfo, error) {
+	first, err := b.consumer.ResolveOffset(ctx, topic, partition, OffsetBeginning())
+	if err != nil {
+		return nil, err
+	}
+	end, err := b.consumer.ResolveOffset(ctx, topic, partition, OffsetEnd())
+	if err != nil {
+		return nil, err
+	}
+
+	info := &PartitionBackupInfo{Partition: partition, FirstOffset: first, EndOffset: end}
+	p := partition
+	for offset := first; offset < end; {
+		messages, err := b.consumer.Receive(ctx, topic, &ReceiveOptions{
+			Partition:   &p,
+			Offset:      offset,
+			MaxMessages: batchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) == 0 {
+			// 剩余偏移量已被清理或不可读，记录后结束该分区
+			b.logger.Warn("Partition returned no messages before end offset",
+				logging.Field{Key: "topic", Value: topic},
+				logging.Field{Key: "partition", Value: partition},
+				logging.Field{Key: "offset", Value: offset},
+				logging.Field{Key: "end_offset", Value: end})
+			break
+		}
+
+		advanced := false
+		for _, msg := range messages {
+			if msg.Offset < offset || msg.Offset >= end {
+				continue
+			}
+			record := &backupRecord{
+				Partition: partition,
+				Offset:    msg.Offset,
+				Key:       msg.Key,
+				Value:     msg.Value,
+				Headers:   msg.Headers,
+				Timestamp: msg.Timestamp,
+				MessageID: msg.MessageID,
+			}
+			if err := writer.writeRecord(record); err != nil {
+				return nil, errors.Wrap(errors.ErrOperation, "failed to write backup record", err)
+			}
+			info.Count++
+			offset = msg.Offset + 1
+			advanced = true
+		}
+		if !advanced {
+			break
+		}
+	}
+	return info, nil
+}
+
+// ExportFile 导出到文件，先写临时文件，成功后再重命名，避免留下不完整的备份
+func (b *BackupManager) ExportFile(ctx context.Context, topic, path string, opts *ExportOptions) (*ExportResult, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidArgument, "failed to create backup file", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	result, err := b.Export(ctx, topic, tmp, opts)
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = errors.Wrap(errors.ErrOperation, "failed to close backup file", closeErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, errors.Wrap(errors.ErrOperation, "failed to move backup file into place", err)
+	}
+	return result, nil
+}
+
+// ImportOptions 导入选项
+type ImportOptions struct {
+	Topic          string `json:"topic,omitempty"`            // 目标主题，默认使用备份中的主题
+	BatchSize      int    `json:"batch_size,omitempty"`       // 每次BatchProduce的消息数，默认500，最大MaxBatchSize
+	Checkpoint     string `json:"checkpoint,omitempty"`       // 检查点文件路径，存在时从中断处继续
+	KeepMessageIDs bool   `json:"keep_message_ids,omitempty"` // 保留原消息ID，否则由服务端重新生成
+	Verify         bool   `json:"verify,omitempty"`           // 导入后比较目标主题偏移量增量与导入消息数
+}
+
+// ImportResult 导入结果
+type ImportResult struct {
+	SourceTopic string        `json:"source_topic"`
+	Topic       string        `json:"topic"`
+	Total       int64         `json:"total"`    // 备份中的消息数
+	Skipped     int64         `json:"skipped"`  // 根据检查点跳过的消息数
+	Produced    int64         `json:"produced"` // 本次写入的消息数
+	Checksum    string        `json:"checksum"`
+	Verified    bool          `json:"verified"`
+	TargetDelta int64         `json:"target_delta,omitempty"` // Verify时目标主题各分区最新偏移量增量之和
+	Duration    time.Duration `json:"duration"`
+}
+
+// importCheckpoint 导入检查点
+type importCheckpoint struct {
+	SourceTopic string    `json:"source_topic"`
+	CreatedAt   time.Time `json:"created_at"` // 备份创建时间，用于识别备份文件
+	Topic       string    `json:"topic"`
+	Done        int64     `json:"done"`              // 从备份开头起连续写入成功的消息数
+	Written     []int64   `json:"written,omitempty"` // Done之后已写入的消息序号（从1开始），批内部分消息失败时产生
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// isWritten 序号为position的消息是否已经写入
+func (c *importCheckpoint) isWritten(position int64) bool {
+	if position <= c.Done {
+		return true
+	}
+	i := sort.Search(len(c.Written), func(i int) bool { return c.Written[i] >= position })
+	return i < len(c.Written) && c.Written[i] == position
+}
+
+// markWritten 记录已写入的消息序号，并把Done推进到第一条未写入的消息之前
+func (c *importCheckpoint) markWritten(positions []int64) {
+	written := make(map[int64]bool, len(c.Written)+len(positions))
+	for _, position := range c.Written {
+		written[position] = true
+	}
+	for _, position := range positions {
+		written[position] = true
+	}
+	for written[c.Done+1] {
+		delete(written, c.Done+1)
+		c.Done++
+	}
+
+	c.Written = make([]int64, 0, len(written))
+	for position := range written {
+		c.Written = append(c.Written, position)
+	}
+	sort.Slice(c.Written, func(i, j int) bool { return c.Written[i] < c.Written[j] })
+}
+
+// Import 读取备份并通过BatchProduce写入目标主题
+// 每批发送后更新检查点并记录其中已写入的消息；某条消息失败时返回错误，
+// 重新运行会跳过检查点中已写入的消息，从第一条失败的消息继续。
+// 投递语义为至少一次：批次发送成功但检查点保存前崩溃时，重新运行会再次发送该批；
+// RPC失败时整批记为失败，即使服务端可能已写入部分消息，重新运行也会重复写入。
+// 消息不带分区发送，目标分区由目标服务端分配，源分区和分区内顺序不保留
+// 读取过程中校验每条消息的CRC，读到文件尾时校验消息总数和整体校验和
+func (b *BackupManager) Import(ctx context.Context, r io.Reader, opts *ImportOptions) (*ImportResult, error) {
+	o := ImportOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 500
+	}
+	if o.BatchSize > MaxBatchSize {
+		o.BatchSize = MaxBatchSize
+	}
+
+	reader, err := newArchiveReader(r)
+	if err != nil {
+		return nil, err
+	}
+	if o.Topic == "" {
+		o.Topic = reader.header.Topic
+	}
+
+	checkpoint := &importCheckpoint{
+		SourceTopic: reader.header.Topic,
+		CreatedAt:   reader.header.CreatedAt,
+		Topic:       o.Topic,
+	}
+	if o.Checkpoint != "" {
+		if err := loadCheckpoint(o.Checkpoint, checkpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	var before int64
+	if o.Verify {
+		if before, err = b.endOffsetSum(ctx, o.Topic); err != nil {
+			return nil, err
+		}
+	}
+
+	b.logger.Info("Importing backup",
+		logging.Field{Key: "source_topic", Value: reader.header.Topic},
+		logging.Field{Key: "topic", Value: o.Topic},
+		logging.Field{Key: "resume_from", Value: checkpoint.Done})
+
+	start := time.Now()
+	result := &ImportResult{SourceTopic: reader.header.Topic, Topic: o.Topic}
+	batch := make([]*Message, 0, o.BatchSize)
+	positions := make([]int64, 0, o.BatchSize) // batch中每条消息在备份中的序号
+	var position int64                         // 当前读到的消息序号
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		sent, err := b.producer.SendBatch(ctx, o.Topic, batch)
+		if sent == nil {
+			return err
+		}
+
+		// 批内失败的消息之后可能已有消息写入，逐条记录，恢复时跳过
+		failed := make(map[int]bool, len(sent.Failures))
+		for _, failure := range sent.Failures {
+			failed[failure.Index] = true
+		}
+		written := make([]int64, 0, len(batch))
+		for i, pos := range positions {
+			if !failed[i] {
+				written = append(written, pos)
+			}
+		}
+		checkpoint.markWritten(written)
+		result.Produced += int64(len(written))
+		if o.Checkpoint != "" {
+			if saveErr := saveCheckpoint(o.Checkpoint, checkpoint); saveErr != nil {
+				return saveErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if len(sent.Failures) > 0 {
+			return errors.New(errors.ErrOperation,
+				fmt.Sprintf("message #%d failed: %s", positions[sent.Failures[0].Index], sent.Failures[0].Error))
+		}
+		batch = batch[:0]
+		positions = positions[:0]
+		return nil
+	}
+
+	for {
+		record, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		position++
+		if checkpoint.isWritten(position) {
+			result.Skipped++
+			continue
+		}
+
+		msg := &Message{
+			Key:       record.Key,
+			Value:     record.Value,
+			Headers:   record.Headers,
+			Timestamp: record.Timestamp,
+		}
+		if o.KeepMessageIDs {
+			msg.MessageID = record.MessageID
+		}
+		batch = append(batch, msg)
+		positions = append(positions, position)
+		if len(batch) >= o.BatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	result.Total = reader.footer.Count
+	result.Checksum = reader.footer.Checksum
+	if result.Skipped+result.Produced != result.Total {
+		return nil, errors.New(errors.ErrValidation,
+			fmt.Sprintf("import count mismatch: %d skipped + %d produced != %d in backup", result.Skipped, result.Produced, result.Total))
+	}
+
+	if o.Verify {
+		after, err := b.endOffsetSum(ctx, o.Topic)
+		if err != nil {
+			return nil, err
+		}
+		result.TargetDelta = after - before
+		if result.TargetDelta != result.Produced {
+			return result, errors.New(errors.ErrValidation,
+				fmt.Sprintf("target topic grew by %d messages, expected %d", result.TargetDelta, result.Produced))
+		}
+		result.Verified = true
+	}
+
+	if o.Checkpoint != "" {
+		if err := os.Remove(o.Checkpoint); err != nil && !os.IsNotExist(err) {
+			b.logger.Warn("Failed to remove import checkpoint", logging.Field{Key: "error", Value: err})
+		}
+	}
+
+	result.Duration = time.Since(start)
+	b.logger.Info("Backup imported",
+		logging.Field{Key: "topic", Value: o.Topic},
+		logging.Field{Key: "produced", Value: result.Produced},
+		logging.Field{Key: "skipped", Value: result.Skipped})
+	return result, nil
+}
+
+// ImportFile 从文件导入
+func (b *BackupManager) ImportFile(ctx context.Context, path string, opts *ImportOptions) (*ImportResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidArgument, "failed to open backup file", err)
+	}
+	defer f.Close()
+	return b.Import(ctx, f, opts)
+}
+
+// VerifyBackup 完整读取备份并校验每条消息、消息总数和整体校验和，不连接服务端
+func VerifyBackup(r io.Reader) (*ExportResult, error) {
+	reader, err := newArchiveReader(r)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, err := reader.next(); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return &ExportResult{
+		Topic:      reader.header.Topic,
+		Format:     reader.format,
+		Partitions: reader.footer.Partitions,
+		Total:      reader.footer.Count,
+		Checksum:   reader.footer.Checksum,
+	}, nil
+}
+
+// endOffsetSum 计算主题所有分区最新偏移量之和
+func (b *BackupManager) endOffsetSum(ctx context.Context, topic string) (int64, error) {
+	info, err := b.topics.Info(ctx, topic)
+	if err != nil {
+		return 0, err
+	}
+	var sum int64
+	for p := int32(0); p < info.Partitions; p++ {
+		end, err := b.consumer.ResolveOffset(ctx, topic, p, OffsetEnd())
+		if err != nil {
+			return 0, err
+		}
+		sum += end
+	}
+	return sum, nil
+}
+
+// loadCheckpoint 读取检查点，文件不存在时保持初始值；检查点属于其他备份或目标主题时返回错误
+func loadCheckpoint(path string, checkpoint *importCheckpoint) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(errors.ErrInvalidArgument, "failed to read checkpoint", err)
+	}
+
+	saved := &importCheckpoint{}
+	if err := json.Unmarshal(data, saved); err != nil {
+		return errors.Wrap(errors.ErrValidation, "invalid checkpoint file", err)
+	}
+	if saved.SourceTopic != checkpoint.SourceTopic || !saved.CreatedAt.Equal(checkpoint.CreatedAt) || saved.Topic != checkpoint.Topic {
+		return errors.New(errors.ErrValidation, "checkpoint belongs to a different backup or target topic")
+	}
+	checkpoint.Done = saved.Done
+	checkpoint.Written = saved.Written
+	sort.Slice(checkpoint.Written, func(i, j int) bool { return checkpoint.Written[i] < checkpoint.Written[j] })
+	return nil
+}
+
+// saveCheckpoint 原子地写入检查点
+func saveCheckpoint(path string, checkpoint *importCheckpoint) error {
+	checkpoint.UpdatedAt = time.Now()
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return errors.Wrap(errors.ErrOperation, "failed to write checkpoint", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrap(errors.ErrOperation, "failed to write checkpoint", err)
+	}
+	return nil
+}
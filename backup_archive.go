@@ -0,0 +1,470 @@
+package fluvio
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// BackupFormat 备份文件格式
+type BackupFormat string
+
+// 备份文件格式常量
+const (
+	BackupNDJSON BackupFormat = "ndjson" // 每行一个JSON对象，便于查看和处理
+	BackupBinary BackupFormat = "binary" // 长度前缀的二进制帧，体积更小，支持任意二进制值
+)
+
+// backupVersion 备份文件格式版本
+const backupVersion = 1
+
+// backupMagic 二进制备份文件头
+var backupMagic = []byte("FLVBAK01")
+
+// 二进制帧类型
+const (
+	frameHeader  byte = 'H'
+	frameMessage byte = 'M'
+	frameFooter  byte = 'F'
+)
+
+// maxFrameSize 单个帧的最大长度，防止损坏的文件导致超大内存分配
+const maxFrameSize = 64 * 1024 * 1024
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// archiveHeader 备份文件头
+type archiveHeader struct {
+	Type       string       `json:"type"`
+	Version    int          `json:"version"`
+	Format     BackupFormat `json:"format"`
+	Topic      string       `json:"topic"`
+	Partitions []int32      `json:"partitions"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// archiveFooter 备份文件尾，包含消息总数和整体校验和
+type archiveFooter struct {
+	Type       string                 `json:"type"`
+	Count      int64                  `json:"count"`
+	Partitions []*PartitionBackupInfo `json:"partitions"`
+	Checksum   string                 `json:"checksum"`
+}
+
+// backupRecord 备份中的单条消息
+type backupRecord struct {
+	Partition int32
+	Offset    int64
+	Key       string
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+	MessageID string
+}
+
+// ndjsonRecord NDJSON格式中的消息行，值不是合法UTF-8时使用base64编码
+type ndjsonRecord struct {
+	Type      string            `json:"type"`
+	Partition int32             `json:"partition"`
+	Offset    int64             `json:"offset"`
+	Key       string            `json:"key,omitempty"`
+	Value     string            `json:"value"`
+	Encoding  string            `json:"encoding,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	MessageID string            `json:"message_id,omitempty"`
+	CRC       uint32            `json:"crc"`
+}
+
+// encodeRecord 将消息编码为与格式无关的二进制形式，用于二进制帧和校验和计算
+func encodeRecord(r *backupRecord) []byte {
+	buf := make([]byte, 0, 64+len(r.Key)+len(r.Value))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(r.Partition))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(r.Offset))
+	var ts int64
+	if !r.Timestamp.IsZero() {
+		ts = r.Timestamp.UnixNano()
+	}
+	buf = binary.BigEndian.AppendUint64(buf, uint64(ts))
+	buf = appendBytes(buf, []byte(r.MessageID))
+	buf = appendBytes(buf, []byte(r.Key))
+
+	keys := make([]string, 0, len(r.Headers))
+	for k := range r.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(keys)))
+	for _, k := range keys {
+		buf = appendBytes(buf, []byte(k))
+		buf = appendBytes(buf, []byte(r.Headers[k]))
+	}
+
+	return appendBytes(buf, r.Value)
+}
+
+// appendBytes 追加长度前缀的字节串
+func appendBytes(buf, data []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+	return append(buf, data...)
+}
+
+// decodeRecord 解码encodeRecord的输出
+func decodeRecord(data []byte) (*backupRecord, error) {
+	d := &recordDecoder{data: data}
+	r := &backupRecord{
+		Partition: int32(d.uint32()),
+		Offset:    int64(d.uint64()),
+	}
+	if ts := int64(d.uint64()); ts != 0 {
+		r.Timestamp = time.Unix(0, ts).UTC()
+	}
+	r.MessageID = string(d.bytes())
+	r.Key = string(d.bytes())
+	if n := d.uint32(); n > 0 && d.err == nil {
+		r.Headers = make(map[string]string, n)
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			k := string(d.bytes())
+			r.Headers[k] = string(d.bytes())
+		}
+	}
+	r.Value = d.bytes()
+
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.data) != 0 {
+		return nil, errors.New(errors.ErrValidation, "trailing bytes in backup record")
+	}
+	return r, nil
+}
+
+// recordDecoder 带边界检查的顺序解码器
+type recordDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *recordDecoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.data) {
+		d.err = errors.New(errors.ErrValidation, "truncated backup record")
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *recordDecoder) uint32() uint32 {
+	b := d.take(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (d *recordDecoder) uint64() uint64 {
+	b := d.take(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (d *recordDecoder) bytes() []byte {
+	n := d.uint32()
+	b := d.take(int(n))
+	if b == nil {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+// archiveWriter 按指定格式写入备份文件，并计算整体校验和
+type archiveWriter struct {
+	format BackupFormat
+	w      *bufio.Writer
+	digest hash.Hash
+	count  int64
+}
+
+// newArchiveWriter 创建备份写入器
+func newArchiveWriter(w io.Writer, format BackupFormat) (*archiveWriter, error) {
+	switch format {
+	case BackupNDJSON, BackupBinary:
+	default:
+		return nil, errors.New(errors.ErrInvalidArgument, fmt.Sprintf("unknown backup format: %s", format))
+	}
+	return &archiveWriter{format: format, w: bufio.NewWriter(w), digest: sha256.New()}, nil
+}
+
+// writeHeader 写入文件头
+func (a *archiveWriter) writeHeader(h *archiveHeader) error {
+	h.Type = "header"
+	h.Version = backupVersion
+	h.Format = a.format
+	if a.format == BackupBinary {
+		if _, err := a.w.Write(backupMagic); err != nil {
+			return err
+		}
+	}
+	return a.writeJSON(frameHeader, h)
+}
+
+// writeRecord 写入一条消息
+func (a *archiveWriter) writeRecord(r *backupRecord) error {
+	encoded := encodeRecord(r)
+	a.digest.Write(encoded)
+	a.count++
+
+	if a.format == BackupBinary {
+		return a.writeFrame(frameMessage, encoded)
+	}
+
+	line := &ndjsonRecord{
+		Type:      "message",
+		Partition: r.Partition,
+		Offset:    r.Offset,
+		Key:       r.Key,
+		Headers:   r.Headers,
+		Timestamp: r.Timestamp,
+		MessageID: r.MessageID,
+		CRC:       crc32.Checksum(encoded, crcTable),
+	}
+	if utf8.Valid(r.Value) {
+		line.Value = string(r.Value)
+	} else {
+		line.Value = base64.StdEncoding.EncodeToString(r.Value)
+		line.Encoding = "base64"
+	}
+	return a.writeJSON(frameMessage, line)
+}
+
+// writeFooter 写入文件尾并刷新缓冲区
+func (a *archiveWriter) writeFooter(partitions []*PartitionBackupInfo) (*archiveFooter, error) {
+	footer := &archiveFooter{
+		Type:       "footer",
+		Count:      a.count,
+		Partitions: partitions,
+		Checksum:   hex.EncodeToString(a.digest.Sum(nil)),
+	}
+	if err := a.writeJSON(frameFooter, footer); err != nil {
+		return nil, err
+	}
+	return footer, a.w.Flush()
+}
+
+// writeJSON 写入JSON帧（NDJSON格式下为一行）
+func (a *archiveWriter) writeJSON(frameType byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if a.format == BackupBinary {
+		return a.writeFrame(frameType, data)
+	}
+	data = append(data, '\n')
+	_, err = a.w.Write(data)
+	return err
+}
+
+// writeFrame 写入二进制帧：类型(1) + 长度(4) + 数据 + CRC32C(4)
+func (a *archiveWriter) writeFrame(frameType byte, payload []byte) error {
+	var head [5]byte
+	head[0] = frameType
+	binary.BigEndian.PutUint32(head[1:], uint32(len(payload)))
+	if _, err := a.w.Write(head[:]); err != nil {
+		return err
+	}
+	if _, err := a.w.Write(payload); err != nil {
+		return err
+	}
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc32.Checksum(payload, crcTable))
+	_, err := a.w.Write(sum[:])
+	return err
+}
+
+// archiveReader 读取备份文件，自动识别格式并校验每条消息和整体校验和
+type archiveReader struct {
+	format BackupFormat
+	r      *bufio.Reader
+	header *archiveHeader
+	footer *archiveFooter
+	digest hash.Hash
+	count  int64
+}
+
+// newArchiveReader 创建备份读取器并读取文件头
+func newArchiveReader(r io.Reader) (*archiveReader, error) {
+	a := &archiveReader{r: bufio.NewReaderSize(r, 1<<20), digest: sha256.New()}
+
+	magic, err := a.r.Peek(len(backupMagic))
+	if err == nil && string(magic) == string(backupMagic) {
+		a.format = BackupBinary
+		if _, err := a.r.Discard(len(backupMagic)); err != nil {
+			return nil, err
+		}
+	} else {
+		a.format = BackupNDJSON
+	}
+
+	frameType, payload, err := a.readFrame()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrValidation, "failed to read backup header", err)
+	}
+	header := &archiveHeader{}
+	if frameType != frameHeader || json.Unmarshal(payload, header) != nil || header.Type != "header" {
+		return nil, errors.New(errors.ErrValidation, "invalid backup header")
+	}
+	if header.Version != backupVersion {
+		return nil, errors.New(errors.ErrValidation, fmt.Sprintf("unsupported backup version: %d", header.Version))
+	}
+	a.header = header
+	return a, nil
+}
+
+// next 读取下一条消息，读到文件尾时校验消息数和校验和并返回io.EOF
+func (a *archiveReader) next() (*backupRecord, error) {
+	if a.footer != nil {
+		return nil, io.EOF
+	}
+
+	frameType, payload, err := a.readFrame()
+	if err == io.EOF {
+		return nil, errors.New(errors.ErrValidation, "backup is truncated: missing footer")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch frameType {
+	case frameMessage:
+		record, encoded, err := a.parseRecord(payload)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrValidation, fmt.Sprintf("corrupt backup record #%d", a.count+1), err)
+		}
+		a.digest.Write(encoded)
+		a.count++
+		return record, nil
+	case frameFooter:
+		footer := &archiveFooter{}
+		if err := json.Unmarshal(payload, footer); err != nil {
+			return nil, errors.Wrap(errors.ErrValidation, "invalid backup footer", err)
+		}
+		if footer.Count != a.count {
+			return nil, errors.New(errors.ErrValidation,
+				fmt.Sprintf("backup message count mismatch: footer says %d, read %d", footer.Count, a.count))
+		}
+		if sum := hex.EncodeToString(a.digest.Sum(nil)); sum != footer.Checksum {
+			return nil, errors.New(errors.ErrValidation, "backup checksum mismatch")
+		}
+		a.footer = footer
+		return nil, io.EOF
+	default:
+		return nil, errors.New(errors.ErrValidation, fmt.Sprintf("unexpected frame type %q", frameType))
+	}
+}
+
+// parseRecord 解析消息帧，返回消息及其二进制编码
+func (a *archiveReader) parseRecord(payload []byte) (*backupRecord, []byte, error) {
+	if a.format == BackupBinary {
+		record, err := decodeRecord(payload)
+		return record, payload, err
+	}
+
+	line := &ndjsonRecord{}
+	if err := json.Unmarshal(payload, line); err != nil {
+		return nil, nil, err
+	}
+	record := &backupRecord{
+		Partition: line.Partition,
+		Offset:    line.Offset,
+		Key:       line.Key,
+		Value:     []byte(line.Value),
+		Headers:   line.Headers,
+		Timestamp: line.Timestamp,
+		MessageID: line.MessageID,
+	}
+	if line.Encoding == "base64" {
+		value, err := base64.StdEncoding.DecodeString(line.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		record.Value = value
+	}
+
+	encoded := encodeRecord(record)
+	if crc32.Checksum(encoded, crcTable) != line.CRC {
+		return nil, nil, errors.New(errors.ErrValidation, "record checksum mismatch")
+	}
+	return record, encoded, nil
+}
+
+// readFrame 读取下一个帧；NDJSON格式下一行为一帧，类型由type字段决定
+func (a *archiveReader) readFrame() (byte, []byte, error) {
+	if a.format == BackupNDJSON {
+		line, err := a.r.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			return 0, nil, io.EOF
+		}
+		if err != nil && err != io.EOF {
+			return 0, nil, err
+		}
+		var probe struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(line, &probe); err != nil {
+			return 0, nil, errors.Wrap(errors.ErrValidation, "invalid backup line", err)
+		}
+		switch probe.Type {
+		case "header":
+			return frameHeader, line, nil
+		case "message":
+			return frameMessage, line, nil
+		case "footer":
+			return frameFooter, line, nil
+		default:
+			return 0, nil, errors.New(errors.ErrValidation, fmt.Sprintf("unknown backup line type %q", probe.Type))
+		}
+	}
+
+	var head [5]byte
+	if _, err := io.ReadFull(a.r, head[:]); err != nil {
+		if err == io.EOF {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(head[1:])
+	if size > maxFrameSize {
+		return 0, nil, errors.New(errors.ErrValidation, fmt.Sprintf("backup frame too large: %d bytes", size))
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(a.r, payload); err != nil {
+		return 0, nil, err
+	}
+	var sum [4]byte
+	if _, err := io.ReadFull(a.r, sum[:]); err != nil {
+		return 0, nil, err
+	}
+	if binary.BigEndian.Uint32(sum[:]) != crc32.Checksum(payload, crcTable) {
+		return 0, nil, errors.New(errors.ErrValidation, "backup frame checksum mismatch")
+	}
+	return head[0], payload, nil
+}
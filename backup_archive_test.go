@@ -0,0 +1,219 @@
+package fluvio
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+// writeTestArchive 按指定格式写出包含records的完整备份
+func writeTestArchive(t *testing.T, format BackupFormat, records []*backupRecord) ([]byte, *archiveFooter) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := newArchiveWriter(&buf, format)
+	if err != nil {
+		t.Fatalf("newArchiveWriter: %v", err)
+	}
+	header := &archiveHeader{Topic: "orders", Partitions: []int32{0, 1}, CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	if err := w.writeHeader(header); err != nil {
+		t.Fatalf("writeHeader: %v", err)
+	}
+	for _, r := range records {
+		if err := w.writeRecord(r); err != nil {
+			t.Fatalf("writeRecord: %v", err)
+		}
+	}
+	footer, err := w.writeFooter([]*PartitionBackupInfo{{Partition: 0, Count: int64(len(records))}})
+	if err != nil {
+		t.Fatalf("writeFooter: %v", err)
+	}
+	return buf.Bytes(), footer
+}
+
+// readTestArchive 读出备份中的所有消息，返回遇到的第一个错误
+func readTestArchive(data []byte) (*archiveReader, []*backupRecord, error) {
+	reader, err := newArchiveReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	var records []*backupRecord
+	for {
+		record, err := reader.next()
+		if err == io.EOF {
+			return reader, records, nil
+		}
+		if err != nil {
+			return reader, records, err
+		}
+		records = append(records, record)
+	}
+}
+
+func testRecords() []*backupRecord {
+	return []*backupRecord{
+		{
+			Partition: 0,
+			Offset:    0,
+			Key:       "order-1",
+			Value:     []byte(`{"id":1,"item":"键盘"}`),
+			Headers:   map[string]string{"content-type": "application/json", "traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+			Timestamp: time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.UTC),
+			MessageID: "msg-1",
+		},
+		{
+			Partition: 0,
+			Offset:    1,
+			Value:     []byte{0xff, 0xfe, 0x00, 0x80, 'a'}, // 非UTF-8
+			Timestamp: time.Date(2024, 5, 1, 12, 0, 1, 0, time.UTC),
+		},
+		{
+			Partition: 1,
+			Offset:    42,
+			Key:       "empty",
+			Value:     []byte{},
+		},
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  BackupFormat
+		records []*backupRecord
+	}{
+		{name: "ndjson", format: BackupNDJSON, records: testRecords()},
+		{name: "binary", format: BackupBinary, records: testRecords()},
+		{name: "ndjson empty", format: BackupNDJSON},
+		{name: "binary empty", format: BackupBinary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, footer := writeTestArchive(t, tt.format, tt.records)
+
+			reader, got, err := readTestArchive(data)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if reader.format != tt.format {
+				t.Errorf("format = %s, want %s", reader.format, tt.format)
+			}
+			if reader.header.Topic != "orders" {
+				t.Errorf("topic = %q, want orders", reader.header.Topic)
+			}
+			if reader.footer.Checksum != footer.Checksum || reader.footer.Count != int64(len(tt.records)) {
+				t.Errorf("footer = %+v, want checksum %s count %d", reader.footer, footer.Checksum, len(tt.records))
+			}
+			if len(got) != len(tt.records) {
+				t.Fatalf("read %d records, want %d", len(got), len(tt.records))
+			}
+			for i, want := range tt.records {
+				assertRecordEqual(t, i, got[i], want)
+			}
+		})
+	}
+}
+
+func TestArchiveChecksumIndependentOfFormat(t *testing.T) {
+	_, ndjson := writeTestArchive(t, BackupNDJSON, testRecords())
+	_, binary := writeTestArchive(t, BackupBinary, testRecords())
+	if ndjson.Checksum != binary.Checksum {
+		t.Errorf("ndjson checksum %s != binary checksum %s", ndjson.Checksum, binary.Checksum)
+	}
+}
+
+func TestArchiveCorruption(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  BackupFormat
+		corrupt func(data []byte) []byte
+	}{
+		{
+			name:   "ndjson missing footer",
+			format: BackupNDJSON,
+			corrupt: func(data []byte) []byte {
+				end := bytes.LastIndexByte(data[:len(data)-1], '\n')
+				return data[:end+1]
+			},
+		},
+		{
+			name:    "ndjson cut mid line",
+			format:  BackupNDJSON,
+			corrupt: func(data []byte) []byte { return data[:len(data)-10] },
+		},
+		{
+			name:   "ndjson changed value",
+			format: BackupNDJSON,
+			corrupt: func(data []byte) []byte {
+				return bytes.Replace(data, []byte("order-1"), []byte("order-2"), 1)
+			},
+		},
+		{
+			name:   "ndjson dropped message",
+			format: BackupNDJSON,
+			corrupt: func(data []byte) []byte {
+				lines := bytes.SplitAfter(data, []byte("\n"))
+				return bytes.Join(append(lines[:1:1], lines[2:]...), nil)
+			},
+		},
+		{
+			name:    "binary cut mid frame",
+			format:  BackupBinary,
+			corrupt: func(data []byte) []byte { return data[:len(data)-10] },
+		},
+		{
+			name:   "binary cut before footer",
+			format: BackupBinary,
+			corrupt: func(data []byte) []byte {
+				// 帧头为类型(1) + 长度(4)
+				return data[:bytes.LastIndex(data, []byte(`{"type":"footer"`))-5]
+			},
+		},
+		{
+			name:   "binary flipped byte",
+			format: BackupBinary,
+			corrupt: func(data []byte) []byte {
+				i := bytes.Index(data, []byte("order-1"))
+				data[i] ^= 0x01
+				return data
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := writeTestArchive(t, tt.format, testRecords())
+			data = tt.corrupt(append([]byte(nil), data...))
+
+			if _, _, err := readTestArchive(data); err == nil {
+				t.Fatal("expected an error for a corrupted backup")
+			}
+			if _, err := VerifyBackup(bytes.NewReader(data)); err == nil {
+				t.Fatal("VerifyBackup accepted a corrupted backup")
+			}
+		})
+	}
+}
+
+func assertRecordEqual(t *testing.T, i int, got, want *backupRecord) {
+	t.Helper()
+	if got.Partition != want.Partition || got.Offset != want.Offset || got.Key != want.Key || got.MessageID != want.MessageID {
+		t.Errorf("record %d = %+v, want %+v", i, got, want)
+	}
+	if !bytes.Equal(got.Value, want.Value) {
+		t.Errorf("record %d value = %x, want %x", i, got.Value, want.Value)
+	}
+	if !got.Timestamp.Equal(want.Timestamp) {
+		t.Errorf("record %d timestamp = %v, want %v", i, got.Timestamp, want.Timestamp)
+	}
+	if len(got.Headers) != len(want.Headers) {
+		t.Errorf("record %d headers = %v, want %v", i, got.Headers, want.Headers)
+	}
+	for k, v := range want.Headers {
+		if got.Headers[k] != v {
+			t.Errorf("record %d header %s = %q, want %q", i, k, got.Headers[k], v)
+		}
+	}
+}
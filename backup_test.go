@@ -0,0 +1,44 @@
+package fluvio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportCheckpointMarkWritten(t *testing.T) {
+	tests := []struct {
+		name        string
+		done        int64
+		written     []int64
+		mark        []int64
+		wantDone    int64
+		wantWritten []int64
+	}{
+		{name: "whole batch", mark: []int64{1, 2, 3}, wantDone: 3, wantWritten: []int64{}},
+		{name: "failure in the middle", mark: []int64{1, 3, 4}, wantDone: 1, wantWritten: []int64{3, 4}},
+		{name: "first message failed", mark: []int64{2, 3}, wantDone: 0, wantWritten: []int64{2, 3}},
+		{name: "resume fills the gap", done: 1, written: []int64{3, 4}, mark: []int64{2, 5}, wantDone: 5, wantWritten: []int64{}},
+		{name: "resume with another gap", done: 1, written: []int64{3, 4}, mark: []int64{2, 6}, wantDone: 4, wantWritten: []int64{6}},
+		{name: "nothing written", done: 2, written: []int64{4}, wantDone: 2, wantWritten: []int64{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &importCheckpoint{Done: tt.done, Written: tt.written}
+			c.markWritten(tt.mark)
+			if c.Done != tt.wantDone || !reflect.DeepEqual(c.Written, tt.wantWritten) {
+				t.Errorf("got done=%d written=%v, want done=%d written=%v", c.Done, c.Written, tt.wantDone, tt.wantWritten)
+			}
+		})
+	}
+}
+
+func TestImportCheckpointIsWritten(t *testing.T) {
+	c := &importCheckpoint{Done: 2, Written: []int64{4, 6}}
+	want := map[int64]bool{1: true, 2: true, 3: false, 4: true, 5: false, 6: true, 7: false}
+	for position, written := range want {
+		if got := c.isWritten(position); got != written {
+			t.Errorf("isWritten(%d) = %v, want %v", position, got, written)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package fluvio
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// ResourceType 资源类型
+type ResourceType string
+
+// 资源类型常量
+const (
+	ResourceTopic         ResourceType = "topic"
+	ResourceConsumerGroup ResourceType = "consumer_group"
+	ResourceSmartModule   ResourceType = "smart_module"
+)
+
+// MatchMode 资源名称匹配方式
+type MatchMode string
+
+// 匹配方式常量
+const (
+	MatchExact MatchMode = "exact" // 名称精确匹配，不查询服务器
+	MatchGlob  MatchMode = "glob"  // path.Match风格的通配符，如 "test-*"
+	MatchRegex MatchMode = "regex" // 正则表达式
+)
+
+// BulkDeleteOptions 批量删除选项
+// Topics、ConsumerGroups、SmartModules按Match指定的方式解析为具体资源名称
+type BulkDeleteOptions struct {
+	Topics         []string  `json:"topics,omitempty"`
+	ConsumerGroups []string  `json:"consumer_groups,omitempty"`
+	SmartModules   []string  `json:"smart_modules,omitempty"`
+	Match          MatchMode `json:"match,omitempty"`   // 默认exact
+	Force          bool      `json:"force,omitempty"`   // 忽略服务端依赖检查
+	DryRun         bool      `json:"dry_run,omitempty"` // 只列出将被删除的资源
+}
+
+// BulkDeleteResult 单个资源的删除结果
+type BulkDeleteResult struct {
+	Name         string       `json:"name"`
+	Type         ResourceType `json:"type"`
+	Success      bool         `json:"success"`
+	Error        string       `json:"error,omitempty"`
+	Dependencies []string     `json:"dependencies,omitempty"` // 仍依赖该资源的其他资源，如在主题上有偏移量的消费者组
+}
+
+// BulkDeleteReport 批量删除报告
+type BulkDeleteReport struct {
+	DryRun    bool                `json:"dry_run"`
+	Results   []*BulkDeleteResult `json:"results"`
+	Requested int                 `json:"requested"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+}
+
+// BulkDelete 批量删除主题、消费者组和SmartModule
+// 主题的依赖（在该主题上有已提交偏移量、且不在本次删除范围内的消费者组）会在结果中列出
+func (a *AdminManager) BulkDelete(ctx context.Context, opts *BulkDeleteOptions) (*BulkDeleteReport, error) {
+	if !*a.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if opts == nil {
+		return nil, errors.New(errors.ErrInvalidArgument, "bulk delete options cannot be nil")
+	}
+
+	a.logger.Debug("Bulk deleting resources",
+		logging.Field{Key: "match", Value: opts.Match},
+		logging.Field{Key: "dry_run", Value: opts.DryRun})
+
+	topics, err := a.selectResources(ctx, ResourceTopic, opts.Topics, opts.Match)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := a.selectResources(ctx, ResourceConsumerGroup, opts.ConsumerGroups, opts.Match)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := a.selectResources(ctx, ResourceSmartModule, opts.SmartModules, opts.Match)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := a.topicDependencies(ctx, topics, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BulkDeleteReport{
+		DryRun:    opts.DryRun,
+		Results:   make([]*BulkDeleteResult, 0, len(topics)+len(groups)+len(modules)),
+		Requested: len(topics) + len(groups) + len(modules),
+	}
+
+	if report.Requested == 0 {
+		a.logger.Info("No resources matched for bulk delete")
+		return report, nil
+	}
+
+	if opts.DryRun {
+		for _, name := range topics {
+			report.Results = append(report.Results, &BulkDeleteResult{Name: name, Type: ResourceTopic, Dependencies: dependencies[name]})
+		}
+		for _, name := range groups {
+			report.Results = append(report.Results, &BulkDeleteResult{Name: name, Type: ResourceConsumerGroup})
+		}
+		for _, name := range modules {
+			report.Results = append(report.Results, &BulkDeleteResult{Name: name, Type: ResourceSmartModule})
+		}
+		a.logger.Info("Bulk delete planned (dry run)", logging.Field{Key: "count", Value: report.Requested})
+		return report, nil
+	}
+
+	resp, err := a.appService.BulkDelete(ctx, &dtos.BulkDeleteRequest{
+		Topics:         topics,
+		ConsumerGroups: groups,
+		SmartModules:   modules,
+		Force:          opts.Force,
+	})
+	if err != nil {
+		a.logger.Error("Failed to bulk delete", logging.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	returned := make(map[ResourceType]map[string]bool, 3)
+	for _, result := range resp.Results {
+		if returned[ResourceType(result.Type)] == nil {
+			returned[ResourceType(result.Type)] = make(map[string]bool)
+		}
+		returned[ResourceType(result.Type)][result.Name] = true
+
+		r := &BulkDeleteResult{
+			Name:    result.Name,
+			Type:    ResourceType(result.Type),
+			Success: result.Success,
+			Error:   result.Error,
+		}
+		if r.Type == ResourceTopic {
+			r.Dependencies = dependencies[r.Name]
+		}
+		if r.Success {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, r)
+	}
+
+	// 服务端未返回结果的资源视为删除失败
+	missing := func(kind ResourceType, names []string) {
+		for _, name := range names {
+			if returned[kind][name] {
+				continue
+			}
+			r := &BulkDeleteResult{Name: name, Type: kind, Error: "no result from server"}
+			if kind == ResourceTopic {
+				r.Dependencies = dependencies[name]
+			}
+			report.Failed++
+			report.Results = append(report.Results, r)
+		}
+	}
+	missing(ResourceTopic, topics)
+	missing(ResourceConsumerGroup, groups)
+	missing(ResourceSmartModule, modules)
+
+	if report.Failed > 0 {
+		a.logger.Error("Bulk delete partially failed",
+			logging.Field{Key: "failed", Value: report.Failed},
+			logging.Field{Key: "total", Value: report.Requested})
+		return report, errors.New(errors.ErrOperation,
+			fmt.Sprintf("failed to delete %d of %d resources", report.Failed, report.Requested))
+	}
+
+	a.logger.Info("Bulk delete completed successfully", logging.Field{Key: "count", Value: report.Succeeded})
+	return report, nil
+}
+
+// selectResources 将名称或模式解析为具体的资源名称列表
+func (a *AdminManager) selectResources(ctx context.Context, kind ResourceType, patterns []string, mode MatchMode) ([]string, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	if mode == "" || mode == MatchExact {
+		return dedupe(patterns), nil
+	}
+
+	matchers := make([]func(string) bool, 0, len(patterns))
+	for _, pattern := range patterns {
+		matcher, err := compileMatcher(pattern, mode)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	names, err := a.listResourceNames(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]string, 0)
+	for _, name := range names {
+		for _, match := range matchers {
+			if match(name) {
+				selected = append(selected, name)
+				break
+			}
+		}
+	}
+	return dedupe(selected), nil
+}
+
+// compileMatcher 根据匹配方式构建匹配函数
+func compileMatcher(pattern string, mode MatchMode) (func(string) bool, error) {
+	switch mode {
+	case MatchGlob:
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrap(errors.ErrInvalidArgument, fmt.Sprintf("invalid glob pattern %q", pattern), err)
+		}
+		return func(name string) bool {
+			ok, _ := path.Match(pattern, name)
+			return ok
+		}, nil
+	case MatchRegex:
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInvalidArgument, fmt.Sprintf("invalid regex pattern %q", pattern), err)
+		}
+		return re.MatchString, nil
+	default:
+		return nil, errors.New(errors.ErrInvalidArgument, fmt.Sprintf("unknown match mode: %s", mode))
+	}
+}
+
+// listResourceNames 通过列表接口获取指定类型的所有资源名称
+func (a *AdminManager) listResourceNames(ctx context.Context, kind ResourceType) ([]string, error) {
+	switch kind {
+	case ResourceTopic:
+		resp, err := a.appService.ListTopics(ctx, &dtos.ListTopicsRequest{})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, errors.New(errors.ErrOperation, resp.Error)
+		}
+		return resp.Topics, nil
+	case ResourceConsumerGroup:
+		resp, err := a.appService.ListConsumerGroups(ctx, &dtos.ListConsumerGroupsRequest{})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, errors.New(errors.ErrOperation, resp.Error)
+		}
+		names := make([]string, len(resp.Groups))
+		for i, group := range resp.Groups {
+			names[i] = group.GroupID
+		}
+		return names, nil
+	case ResourceSmartModule:
+		resp, err := a.appService.ListSmartModules(ctx, &dtos.ListSmartModulesRequest{})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, errors.New(errors.ErrOperation, resp.Error)
+		}
+		names := make([]string, len(resp.Modules))
+		for i, module := range resp.Modules {
+			names[i] = module.Name
+		}
+		return names, nil
+	default:
+		return nil, errors.New(errors.ErrInvalidArgument, fmt.Sprintf("unknown resource type: %s", kind))
+	}
+}
+
+// topicDependencies 找出在待删除主题上有已提交偏移量、且本身不在删除范围内的消费者组
+func (a *AdminManager) topicDependencies(ctx context.Context, topics, deletedGroups []string) (map[string][]string, error) {
+	dependencies := make(map[string][]string)
+	if len(topics) == 0 {
+		return dependencies, nil
+	}
+
+	selected := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		selected[topic] = true
+	}
+	skip := make(map[string]bool, len(deletedGroups))
+	for _, group := range deletedGroups {
+		skip[group] = true
+	}
+
+	groups, err := a.listResourceNames(ctx, ResourceConsumerGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, groupID := range groups {
+		if skip[groupID] {
+			continue
+		}
+		offsets, err := a.committedTopics(ctx, groupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, topic := range offsets {
+			if selected[topic] {
+				dependencies[topic] = append(dependencies[topic], string(ResourceConsumerGroup)+":"+groupID)
+			}
+		}
+	}
+	return dependencies, nil
+}
+
+// committedTopics 获取消费者组有已提交偏移量的主题列表
+func (a *AdminManager) committedTopics(ctx context.Context, groupID string) ([]string, error) {
+	resp, err := a.appService.DescribeConsumerGroup(ctx, &dtos.DescribeConsumerGroupRequest{GroupID: groupID})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	topics := make([]string, 0, len(resp.Group.Offsets))
+	for _, offset := range resp.Group.Offsets {
+		topics = append(topics, offset.Topic)
+	}
+	return dedupe(topics), nil
+}
+
+// dedupe 去重并排序
+func dedupe(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
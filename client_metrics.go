@@ -0,0 +1,39 @@
+package fluvio
+
+import (
+	"net/http"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/metrics"
+)
+
+// MetricsSink 客户端指标接收器，可对接任意指标系统
+type MetricsSink = metrics.Sink
+
+// MetricsLabels 指标标签
+type MetricsLabels = metrics.Labels
+
+// MetricsRegistry 内置的指标注册表，实现MetricsSink和http.Handler（Prometheus文本格式）
+type MetricsRegistry = metrics.Registry
+
+// NewMetricsRegistry 创建指标注册表，buckets为RPC延迟直方图的桶（秒），为空时使用默认值
+func NewMetricsRegistry(buckets ...float64) *MetricsRegistry {
+	return metrics.NewRegistry(buckets...)
+}
+
+// Metrics 获取客户端指标接收器，未启用指标时返回nil
+func (c *Client) Metrics() MetricsSink {
+	if !c.config.Client.Metrics {
+		return nil
+	}
+	return c.metrics
+}
+
+// MetricsHandler 获取以Prometheus文本格式输出指标的http.Handler
+// 未启用指标或自定义的MetricsSink未实现http.Handler时返回nil
+func (c *Client) MetricsHandler() http.Handler {
+	if !c.config.Client.Metrics {
+		return nil
+	}
+	handler, _ := c.metrics.(http.Handler)
+	return handler
+}
@@ -0,0 +1,45 @@
+package fluvio
+
+import (
+	"context"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/tracing"
+)
+
+// Tracer 追踪器，对接OpenTelemetry等追踪系统时实现此接口
+type Tracer = tracing.Tracer
+
+// Span 追踪区间
+type Span = tracing.Span
+
+// SpanContext 可跨进程传播的span标识
+type SpanContext = tracing.SpanContext
+
+// SpanKind span类型
+type SpanKind = tracing.SpanKind
+
+// SpanAttribute span属性
+type SpanAttribute = tracing.Attribute
+
+// span类型常量
+const (
+	SpanKindInternal = tracing.SpanKindInternal
+	SpanKindClient   = tracing.SpanKindClient
+	SpanKindProducer = tracing.SpanKindProducer
+	SpanKindConsumer = tracing.SpanKindConsumer
+)
+
+// ContextWithSpanContext 将SpanContext放入ctx，Tracer实现用它把新span交给SDK继续传播
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return tracing.ContextWithSpanContext(ctx, sc)
+}
+
+// SpanContextFromContext 获取ctx中的SpanContext，Tracer实现用它取得上游span
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	return tracing.SpanContextFromContext(ctx)
+}
+
+// Tracer 获取客户端使用的追踪器，未启用追踪时为NoopTracer
+func (c *Client) Tracer() Tracer {
+	return c.tracer
+}
@@ -0,0 +1,261 @@
+package fluvio
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// MetricSample 服务端指标样本
+type MetricSample struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Value     float64           `json:"value"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// Key 返回样本的唯一标识，格式为 name{k1="v1",k2="v2"}，标签按名称排序
+func (m *MetricSample) Key() string {
+	if len(m.Labels) == 0 {
+		return m.Name
+	}
+
+	keys := make([]string, 0, len(m.Labels))
+	for k := range m.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(m.Name)
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteString(`="`)
+		b.WriteString(m.Labels[k])
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Metrics 获取服务端指标，names和labels为空表示不过滤
+func (a *AdminManager) Metrics(ctx context.Context, names []string, labels map[string]string) ([]*MetricSample, error) {
+	if !*a.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	a.logger.Debug("Getting cluster metrics", logging.Field{Key: "names", Value: names})
+
+	resp, err := a.appService.GetMetrics(ctx, &dtos.GetMetricsRequest{
+		MetricNames: names,
+		Labels:      labels,
+	})
+	if err != nil {
+		a.logger.Error("Failed to get cluster metrics", logging.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	samples := make([]*MetricSample, len(resp.Metrics))
+	for i, metric := range resp.Metrics {
+		samples[i] = &MetricSample{
+			Name:      metric.Name,
+			Labels:    metric.Labels,
+			Value:     metric.Value,
+			Timestamp: metric.Timestamp,
+		}
+	}
+
+	a.logger.Debug("Cluster metrics retrieved successfully", logging.Field{Key: "count", Value: len(samples)})
+	return samples, nil
+}
+
+// ClusterMetricsSink 接收MetricsCollector每次采集到的服务端指标
+// Consume在采集协程中同步调用，实现不应长时间阻塞
+type ClusterMetricsSink interface {
+	Consume(samples []*MetricSample)
+}
+
+// ClusterMetricsSinkFunc 函数形式的ClusterMetricsSink
+type ClusterMetricsSinkFunc func(samples []*MetricSample)
+
+// Consume 实现ClusterMetricsSink接口
+func (f ClusterMetricsSinkFunc) Consume(samples []*MetricSample) {
+	f(samples)
+}
+
+// MetricsCollectorOptions 指标采集器选项
+type MetricsCollectorOptions struct {
+	Interval time.Duration        `json:"interval,omitempty"` // 采集间隔，默认15秒
+	Names    []string             `json:"names,omitempty"`    // 只采集指定名称的指标
+	Labels   map[string]string    `json:"labels,omitempty"`   // 只采集匹配标签的指标
+	Sinks    []ClusterMetricsSink `json:"-"`                  // 每次采集成功后转发的目标
+}
+
+// MetricsCollector 周期性采集服务端指标并缓存最新结果
+type MetricsCollector struct {
+	admin *AdminManager
+	opts  MetricsCollectorOptions
+
+	mu          sync.RWMutex
+	latest      map[string]*MetricSample
+	collectedAt time.Time
+	lastErr     error
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewMetricsCollector 创建指标采集器
+func (a *AdminManager) NewMetricsCollector(opts *MetricsCollectorOptions) *MetricsCollector {
+	o := MetricsCollectorOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 15 * time.Second
+	}
+
+	return &MetricsCollector{
+		admin:  a,
+		opts:   o,
+		latest: make(map[string]*MetricSample),
+	}
+}
+
+// Start 启动后台采集，ctx取消或调用Stop后停止
+func (c *MetricsCollector) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done != nil {
+		return errors.New(errors.ErrOperation, "metrics collector already running")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go c.run(ctx, c.done)
+
+	c.admin.logger.Info("Metrics collector started", logging.Field{Key: "interval", Value: c.opts.Interval})
+	return nil
+}
+
+// Stop 停止采集并等待后台协程退出
+func (c *MetricsCollector) Stop() {
+	c.mu.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel, c.done = nil, nil
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Collect 立即执行一次采集，更新缓存并转发给Sinks
+func (c *MetricsCollector) Collect(ctx context.Context) ([]*MetricSample, error) {
+	samples, err := c.admin.Metrics(ctx, c.opts.Names, c.opts.Labels)
+
+	c.mu.Lock()
+	c.lastErr = err
+	if err == nil {
+		latest := make(map[string]*MetricSample, len(samples))
+		for _, sample := range samples {
+			latest[sample.Key()] = sample
+		}
+		c.latest = latest
+		c.collectedAt = time.Now()
+	}
+	c.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sink := range c.opts.Sinks {
+		sink.Consume(samples)
+	}
+	return samples, nil
+}
+
+// Latest 获取最近一次采集到的所有指标
+func (c *MetricsCollector) Latest() []*MetricSample {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	samples := make([]*MetricSample, 0, len(c.latest))
+	for _, sample := range c.latest {
+		samples = append(samples, sample)
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Key() < samples[j].Key() })
+	return samples
+}
+
+// Get 获取最近一次采集到的指定指标，labels需与样本标签完全一致
+func (c *MetricsCollector) Get(name string, labels map[string]string) (*MetricSample, bool) {
+	key := (&MetricSample{Name: name, Labels: labels}).Key()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	sample, ok := c.latest[key]
+	return sample, ok
+}
+
+// CollectedAt 获取最近一次成功采集的时间
+func (c *MetricsCollector) CollectedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.collectedAt
+}
+
+// LastError 获取最近一次采集的错误，成功时为nil
+func (c *MetricsCollector) LastError() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastErr
+}
+
+// run 采集循环，ctx结束（包括父ctx取消）时清除运行状态，之后可以再次Start
+func (c *MetricsCollector) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer func() {
+		c.mu.Lock()
+		if c.done == done {
+			c.cancel()
+			c.cancel, c.done = nil, nil
+		}
+		c.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Collect(ctx); err != nil && ctx.Err() == nil {
+			c.admin.logger.Warn("Metrics collection failed", logging.Field{Key: "error", Value: err})
+		}
+
+		select {
+		case <-ctx.Done():
+			c.admin.logger.Info("Metrics collector stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+)
+
+// healthStatus 健康检查结果
+type healthStatus struct {
+	Host    string `json:"host"`
+	Port    int    `json:"port"`
+	Healthy bool   `json:"healthy"`
+	Latency string `json:"latency,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// runHealth 检查服务端健康状态，不健康时返回错误
+func runHealth(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("health", "health [flags]")
+	detailed := fs.Bool("detailed", false, "request per-component health (logged at info level)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	status := &healthStatus{Host: g.host, Port: g.port}
+	checkErr := client.HealthCheckDetailed(ctx, *detailed)
+	if checkErr == nil {
+		status.Healthy = true
+		if latency, err := client.Ping(ctx); err == nil {
+			status.Latency = latency.String()
+		}
+	} else {
+		status.Error = checkErr.Error()
+	}
+
+	t := &table{headers: []string{"ADDRESS", "HEALTHY", "LATENCY", "ERROR"}}
+	t.add(net.JoinHostPort(g.host, strconv.Itoa(g.port)), status.Healthy, status.Latency, status.Error)
+	if err := out.print(status, t); err != nil {
+		return err
+	}
+	return checkErr
+}
+
+// clusterSummary 集群信息汇总
+type clusterSummary struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	ControllerID int32  `json:"controller_id"`
+	SDKVersion   string `json:"sdk_version"`
+}
+
+// runClusterInfo 查看集群状态
+func runClusterInfo(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("cluster info", "cluster info [flags]")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	info, err := client.Admin().ClusterInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	summary := &clusterSummary{
+		ID:           info.ID,
+		Status:       info.Status,
+		ControllerID: info.ControllerID,
+		SDKVersion:   fluvio.Version(),
+	}
+	t := &table{headers: []string{"ID", "STATUS", "CONTROLLER", "SDK VERSION"}}
+	t.add(summary.ID, summary.Status, summary.ControllerID, summary.SDKVersion)
+	return out.print(summary, t)
+}
+
+// runClusterBrokers 列出Broker
+func runClusterBrokers(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("cluster brokers", "cluster brokers [flags]")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	brokers, err := client.Admin().Brokers(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"ID", "HOST", "PORT", "STATUS"}}
+	for _, b := range brokers {
+		t.add(b.ID, b.Host, b.Port, b.Status)
+	}
+	return out.print(brokers, t)
+}
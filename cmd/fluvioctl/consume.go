@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+)
+
+// consumedRecord 消费输出的记录格式
+type consumedRecord struct {
+	Topic     string            `json:"topic"`
+	Partition int32             `json:"partition"`
+	Offset    int64             `json:"offset"`
+	Key       string            `json:"key,omitempty"`
+	Value     string            `json:"value"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// runConsume 消费消息
+// 未指定起始位置时：--follow 只消费新消息，否则从头读取；指定 --group 时由服务端从已提交位置继续
+func runConsume(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("consume", "consume <topic> [flags]")
+	fromBeginning := fs.Bool("from-beginning", false, "start from the earliest offset")
+	follow := fs.Bool("follow", false, "keep streaming new messages until interrupted")
+	fs.BoolVar(follow, "f", false, "shorthand for --follow")
+	group := fs.String("group", "", "consumer group")
+	noCommit := fs.Bool("no-commit", false, "do not commit offsets for --group")
+	partition := fs.Int("partition", 0, "partition to consume")
+	offset := fs.Int64("offset", -1, "start from an absolute offset")
+	tail := fs.Int64("tail", 0, "start N messages before the end")
+	since := fs.String("since", "", "start from the first message at or after this time (RFC3339)")
+	maxMessages := fs.Int("max", 0, "stop after N messages (default 10 without --follow, unlimited with --follow)")
+	showMeta := fs.Bool("show-meta", false, "prefix table output with partition, offset and key")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "topic name"); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	from, err := startSpec(*fromBeginning, *offset, *tail, *since)
+	if err != nil {
+		return err
+	}
+	if from == nil && *group == "" {
+		if *follow {
+			from = fluvio.OffsetEnd()
+		} else {
+			from = fluvio.OffsetBeginning()
+		}
+	}
+	if *maxMessages == 0 && !*follow {
+		*maxMessages = 10
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	topic := args[0]
+	p := int32(*partition)
+	consumer := client.Consumer()
+	w := &recordWriter{out: out, w: os.Stdout, showMeta: *showMeta}
+
+	handle := func(msg *fluvio.ConsumedMessage) error {
+		if err := w.write(msg); err != nil {
+			return err
+		}
+		if *group != "" && !*noCommit {
+			return consumer.CommitPartition(ctx, topic, *group, msg.Partition, msg.Offset+1)
+		}
+		return nil
+	}
+
+	if !*follow {
+		messages, err := consumer.Receive(ctx, topic, &fluvio.ReceiveOptions{
+			Group:       *group,
+			Partition:   &p,
+			From:        from,
+			MaxMessages: *maxMessages,
+		})
+		if err != nil {
+			return err
+		}
+		for _, msg := range messages {
+			if err := handle(msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := consumer.Stream(ctx, topic, &fluvio.StreamOptions{
+		Group:      *group,
+		Partition:  &p,
+		From:       from,
+		BufferSize: 100,
+	})
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for msg := range stream {
+		if err := handle(msg); err != nil {
+			return err
+		}
+		count++
+		if *maxMessages > 0 && count >= *maxMessages {
+			cancel()
+			break
+		}
+	}
+	return nil
+}
+
+// startSpec 根据命令行参数构建起始位置，均未指定时返回nil
+func startSpec(fromBeginning bool, offset, tail int64, since string) (*fluvio.OffsetSpec, error) {
+	var specs []*fluvio.OffsetSpec
+	if fromBeginning {
+		specs = append(specs, fluvio.OffsetBeginning())
+	}
+	if offset >= 0 {
+		specs = append(specs, fluvio.OffsetAbsolute(offset))
+	}
+	if tail > 0 {
+		specs = append(specs, fluvio.OffsetFromEnd(tail))
+	}
+	if since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --since: %w", err)
+		}
+		specs = append(specs, fluvio.OffsetAtTime(t))
+	}
+
+	switch len(specs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return specs[0], nil
+	default:
+		return nil, fmt.Errorf("--from-beginning, --offset, --tail and --since are mutually exclusive")
+	}
+}
+
+// recordWriter 逐条输出消息
+// table格式每行输出消息值，json格式每行一个JSON对象，yaml格式每条消息一个文档
+type recordWriter struct {
+	out      *printer
+	w        io.Writer
+	showMeta bool
+}
+
+// write 输出一条消息
+func (r *recordWriter) write(msg *fluvio.ConsumedMessage) error {
+	record := &consumedRecord{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     string(msg.Value),
+		Headers:   msg.Headers,
+		Timestamp: msg.Timestamp,
+	}
+
+	switch r.out.format {
+	case formatJSON:
+		return json.NewEncoder(r.w).Encode(record)
+	case formatYAML:
+		fmt.Fprintln(r.w, "---")
+		return r.out.yaml(record)
+	default:
+		if r.showMeta {
+			_, err := fmt.Fprintf(r.w, "%d:%d\t%s\t%s\n", record.Partition, record.Offset, record.Key, record.Value)
+			return err
+		}
+		_, err := fmt.Fprintln(r.w, record.Value)
+		return err
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+)
+
+// runGroupList 列出消费者组
+func runGroupList(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("group list", "group list [flags]")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	groups, err := client.Admin().ConsumerGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"GROUP", "STATE"}}
+	for _, group := range groups {
+		state := group.State
+		if state == "" {
+			state = "-" // 服务端不返回状态
+		}
+		t.add(group.GroupID, state)
+	}
+	return out.print(groups, t)
+}
+
+// runGroupDescribe 查看消费者组详情和消费延迟
+func runGroupDescribe(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("group describe", "group describe <group> [flags]")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "group id"); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	lag, err := client.Admin().ConsumerGroupLag(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"TOPIC", "PARTITION", "COMMITTED", "HIGH WATERMARK", "LAG"}}
+	for _, p := range lag.Partitions {
+		committed := "-"
+		if p.CommittedOffset >= 0 {
+			committed = strconv.FormatInt(p.CommittedOffset, 10)
+		}
+		t.add(p.Topic, p.Partition, committed, p.HighWatermark, p.Lag)
+	}
+
+	if out.format == formatTable {
+		fmt.Printf("Group:     %s\nTotal lag: %d\n\n", lag.GroupID, lag.TotalLag)
+	}
+	return out.print(lag, t)
+}
+
+// runGroupReset 重置消费者组偏移量，默认只输出计划，--execute 时才提交
+func runGroupReset(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("group reset", "group reset <group> --topic <topic> (--to-earliest|--to-latest|--to-offset N|--to-datetime T|--shift N) [--execute]")
+	topic := fs.String("topic", "", "topic to reset (required)")
+	partitions := fs.String("partitions", "", "comma-separated partitions (default all)")
+	toEarliest := fs.Bool("to-earliest", false, "reset to the earliest offset")
+	toLatest := fs.Bool("to-latest", false, "reset to the latest offset")
+	toOffset := fs.Int64("to-offset", -1, "reset to an absolute offset")
+	toDatetime := fs.String("to-datetime", "", "reset to the first message at or after this time (RFC3339)")
+	shift := fs.Int64("shift", 0, "shift the committed offset by N (negative to rewind)")
+	execute := fs.Bool("execute", false, "commit the new offsets (default is a dry run)")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "group id"); err != nil {
+		return err
+	}
+	if *topic == "" {
+		fs.Usage()
+		return fmt.Errorf("--topic is required")
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	opts := &fluvio.ResetOffsetsOptions{
+		Topic:  *topic,
+		Shift:  *shift,
+		DryRun: !*execute,
+	}
+
+	var targets []*fluvio.OffsetSpec
+	if *toEarliest {
+		targets = append(targets, fluvio.OffsetBeginning())
+	}
+	if *toLatest {
+		targets = append(targets, fluvio.OffsetEnd())
+	}
+	if *toOffset >= 0 {
+		targets = append(targets, fluvio.OffsetAbsolute(*toOffset))
+	}
+	if *toDatetime != "" {
+		t, err := time.Parse(time.RFC3339, *toDatetime)
+		if err != nil {
+			return fmt.Errorf("invalid --to-datetime: %w", err)
+		}
+		targets = append(targets, fluvio.OffsetAtTime(t))
+	}
+	if len(targets) > 1 {
+		return fmt.Errorf("only one of --to-earliest, --to-latest, --to-offset and --to-datetime may be set")
+	}
+	if len(targets) == 1 {
+		opts.To = targets[0]
+	}
+
+	if *partitions != "" {
+		for _, part := range strings.Split(*partitions, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid partition %q", part)
+			}
+			opts.Partitions = append(opts.Partitions, int32(id))
+		}
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.Admin().ResetConsumerGroupOffsets(ctx, args[0], opts)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"TOPIC", "PARTITION", "CURRENT", "TARGET", "APPLIED", "ERROR"}}
+	for _, plan := range result.Plans {
+		current := "-"
+		if plan.CurrentOffset >= 0 {
+			current = strconv.FormatInt(plan.CurrentOffset, 10)
+		}
+		t.add(plan.Topic, plan.Partition, current, plan.TargetOffset, plan.Applied, plan.Error)
+	}
+	if err := out.print(result, t); err != nil {
+		return err
+	}
+	if result.DryRun && out.format == formatTable {
+		fmt.Println("\nDry run only; re-run with --execute to commit.")
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+// Command fluvioctl 是基于Fluvio Go SDK的命令行工具
+//
+// 用法：
+//
+//	fluvioctl <command> [subcommand] [flags] [args]
+//
+// 连接参数（--host、--port、--tls-*等）和输出格式（-o table|json|yaml）
+// 可以出现在任意子命令之后
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+)
+
+// command 子命令
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+	subs    []*command
+}
+
+// commands 顶层命令
+var commands = []*command{
+	{name: "topic", summary: "Manage topics", subs: []*command{
+		{name: "create", summary: "Create a topic", run: runTopicCreate},
+		{name: "list", summary: "List topics", run: runTopicList},
+		{name: "describe", summary: "Describe a topic", run: runTopicDescribe},
+		{name: "delete", summary: "Delete topics", run: runTopicDelete},
+	}},
+	{name: "produce", summary: "Produce messages from stdin or files", run: runProduce},
+	{name: "consume", summary: "Consume messages from a topic", run: runConsume},
+	{name: "tail", summary: "Follow one or more topics with filters and templates", run: runTail},
+	{name: "group", summary: "Manage consumer groups", subs: []*command{
+		{name: "list", summary: "List consumer groups", run: runGroupList},
+		{name: "describe", summary: "Describe a consumer group", run: runGroupDescribe},
+		{name: "reset", summary: "Reset consumer group offsets", run: runGroupReset},
+	}},
+	{name: "smartmodule", summary: "Manage SmartModules", subs: []*command{
+		{name: "list", summary: "List SmartModules", run: runSmartModuleList},
+		{name: "describe", summary: "Describe a SmartModule", run: runSmartModuleDescribe},
+		{name: "create", summary: "Create a SmartModule from a .wasm file", run: runSmartModuleCreate},
+		{name: "delete", summary: "Delete SmartModules", run: runSmartModuleDelete},
+	}},
+	{name: "mirror", summary: "Mirror a topic to another gateway", run: runMirror},
+	{name: "backup", summary: "Export and import topic data", subs: []*command{
+		{name: "export", summary: "Export a topic to a local archive", run: runBackupExport},
+		{name: "import", summary: "Import an archive into a topic", run: runBackupImport},
+		{name: "verify", summary: "Verify archive checksums offline", run: runBackupVerify},
+	}},
+	{name: "health", summary: "Check server health", run: runHealth},
+	{name: "cluster", summary: "Show cluster information", subs: []*command{
+		{name: "info", summary: "Show cluster status", run: runClusterInfo},
+		{name: "brokers", summary: "List brokers", run: runClusterBrokers},
+	}},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := dispatch(ctx, commands, os.Args[1:], "fluvioctl"); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// dispatch 查找并执行子命令
+func dispatch(ctx context.Context, cmds []*command, args []string, prefix string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printUsage(cmds, prefix)
+		return flag.ErrHelp
+	}
+
+	for _, cmd := range cmds {
+		if cmd.name != args[0] {
+			continue
+		}
+		if len(cmd.subs) > 0 {
+			return dispatch(ctx, cmd.subs, args[1:], prefix+" "+cmd.name)
+		}
+		return cmd.run(ctx, args[1:])
+	}
+
+	printUsage(cmds, prefix)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+// printUsage 打印命令列表
+func printUsage(cmds []*command, prefix string) {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags] [args]\n\nCommands:\n", prefix)
+	for _, cmd := range cmds {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for command flags.\n", prefix)
+}
+
+// globalFlags 所有子命令共享的连接和输出参数
+type globalFlags struct {
+	host           string
+	port           int
+	endpoints      string
+	lb             string
+	healthCheck    time.Duration
+	timeout        time.Duration
+	connectTimeout time.Duration
+	retries        int
+	retryBackoff   time.Duration
+	tlsCert        string
+	tlsKey         string
+	tlsCA          string
+	tls            bool
+	tlsServerName  string
+	insecure       bool
+	token          string
+	apiKey         string
+	keepAlive      time.Duration
+	logLevel       string
+	output         string
+}
+
+// newFlagSet 创建子命令参数集，并注册共享参数
+func newFlagSet(name, usage string) (*flag.FlagSet, *globalFlags) {
+	g := &globalFlags{}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: fluvioctl %s\n\nFlags:\n", usage)
+		fs.PrintDefaults()
+	}
+
+	fs.StringVar(&g.host, "host", envOr("FLUVIO_HOST", "localhost"), "server host (env FLUVIO_HOST)")
+	fs.IntVar(&g.port, "port", envIntOr("FLUVIO_PORT", 50051), "server port (env FLUVIO_PORT)")
+	fs.StringVar(&g.endpoints, "endpoints", os.Getenv("FLUVIO_ENDPOINTS"), "comma-separated host:port list, overrides --host/--port (env FLUVIO_ENDPOINTS)")
+	fs.StringVar(&g.lb, "lb", "", "load balancing policy with --endpoints: pick_first, round_robin")
+	fs.DurationVar(&g.healthCheck, "health-check-interval", 0, "eject endpoints failing HealthCheck, probed at this interval (0 disables)")
+	fs.DurationVar(&g.timeout, "timeout", 30*time.Second, "call timeout")
+	fs.DurationVar(&g.connectTimeout, "connect-timeout", 0, "connect timeout (defaults to --timeout)")
+	fs.IntVar(&g.retries, "retries", 0, "max retries per call (0 keeps the SDK default)")
+	fs.DurationVar(&g.retryBackoff, "retry-backoff", time.Second, "backoff between retries")
+	fs.StringVar(&g.tlsCert, "tls-cert", "", "client certificate file")
+	fs.StringVar(&g.tlsKey, "tls-key", "", "client key file")
+	fs.StringVar(&g.tlsCA, "tls-ca", "", "CA certificate file")
+	fs.BoolVar(&g.tls, "tls", false, "enable TLS using the system root certificates")
+	fs.StringVar(&g.tlsServerName, "tls-server-name", "", "server name used to verify the server certificate")
+	fs.BoolVar(&g.insecure, "insecure", false, "enable TLS without verifying the server certificate")
+	fs.StringVar(&g.token, "token", os.Getenv("FLUVIO_TOKEN"), "bearer token (env FLUVIO_TOKEN)")
+	fs.StringVar(&g.apiKey, "api-key", os.Getenv("FLUVIO_API_KEY"), "API key (env FLUVIO_API_KEY)")
+	fs.DurationVar(&g.keepAlive, "keepalive", 0, "keepalive interval (0 keeps the SDK default)")
+	fs.StringVar(&g.logLevel, "log-level", "error", "SDK log level: debug, info, warn, error")
+	fs.StringVar(&g.output, "o", "table", "output format: table, json, yaml")
+	return fs, g
+}
+
+// clientOptions 将命令行参数映射为ClientOption
+func (g *globalFlags) clientOptions() ([]fluvio.ClientOption, error) {
+	level, err := logging.ParseLevel(g.logLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --log-level: %w", err)
+	}
+
+	connectTimeout := g.connectTimeout
+	if connectTimeout == 0 {
+		connectTimeout = g.timeout
+	}
+
+	opts := []fluvio.ClientOption{
+		fluvio.WithAddress(g.host, g.port),
+		fluvio.WithTimeouts(connectTimeout, g.timeout),
+		// 日志写到stderr，避免与命令输出混在一起
+		fluvio.WithLogger(logging.NewStandardLogger(os.Stderr, level)),
+	}
+	if g.endpoints != "" {
+		var endpoints []string
+		for _, endpoint := range strings.Split(g.endpoints, ",") {
+			if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+		opts = append(opts, fluvio.WithEndpoints(endpoints...))
+	}
+	if g.lb != "" {
+		opts = append(opts, fluvio.WithLoadBalancing(fluvio.LoadBalancingPolicy(g.lb)))
+	}
+	if g.healthCheck > 0 {
+		opts = append(opts, fluvio.WithEndpointHealthCheck(g.healthCheck))
+	}
+	if g.retries > 0 {
+		opts = append(opts, fluvio.WithRetry(g.retries, g.retryBackoff))
+	}
+	if g.tls || g.insecure || g.tlsCert != "" || g.tlsKey != "" || g.tlsCA != "" {
+		opts = append(opts, fluvio.WithTLS(g.tlsCert, g.tlsKey, g.tlsCA))
+	}
+	if g.tlsServerName != "" {
+		opts = append(opts, fluvio.WithTLSServerName(g.tlsServerName))
+	}
+	if g.insecure {
+		opts = append(opts, fluvio.WithInsecure())
+	}
+	if g.token != "" {
+		opts = append(opts, fluvio.WithTokenSource(fluvio.StaticTokenSource(g.token)))
+	}
+	if g.apiKey != "" {
+		opts = append(opts, fluvio.WithAPIKey(g.apiKey))
+	}
+	if g.keepAlive > 0 {
+		opts = append(opts, fluvio.WithKeepAlive(g.keepAlive))
+	}
+	return opts, nil
+}
+
+// connect 创建客户端并连接
+func (g *globalFlags) connect(ctx context.Context) (*fluvio.Client, error) {
+	opts, err := g.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+	client, err := fluvio.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// printer 创建输出器
+func (g *globalFlags) printer() (*printer, error) {
+	return newPrinter(os.Stdout, g.output)
+}
+
+// parseArgs 解析参数，允许标志和位置参数交替出现
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		// "--" 之后的内容全部作为位置参数
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// requireArgs 检查位置参数数量
+func requireArgs(fs *flag.FlagSet, args []string, min int, what string) error {
+	if len(args) < min {
+		fs.Usage()
+		return fmt.Errorf("missing %s", what)
+	}
+	return nil
+}
+
+// envOr 读取环境变量，未设置时返回默认值
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// envIntOr 读取整数环境变量，未设置或无效时返回默认值
+func envIntOr(key string, def int) int {
+	var v int
+	if _, err := fmt.Sscanf(os.Getenv(key), "%d", &v); err == nil {
+		return v
+	}
+	return def
+}
+
+// keyValueFlag 可重复的 key=value 参数
+type keyValueFlag map[string]string
+
+func (f keyValueFlag) String() string {
+	parts := make([]string, 0, len(f))
+	for k, v := range f {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func (f keyValueFlag) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	f[k] = v
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+)
+
+// runMirror 将源主题持续镜像到另一个网关上的目标主题
+func runMirror(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("mirror", "mirror <source-topic> [target-topic] --target-host HOST [flags]")
+	targetHost := fs.String("target-host", "", "target server host (required)")
+	targetPort := fs.Int("target-port", 50051, "target server port")
+	group := fs.String("group", "fluvioctl-mirror", "source consumer group used to track progress")
+	batchSize := fs.Int("batch-size", 100, "max messages per batch produce call")
+	flush := fs.Duration("flush-interval", 500*time.Millisecond, "max wait before sending a partial batch")
+	originHeader := fs.String("origin-header", fluvio.DefaultMirrorOriginHeader, "header used for loop prevention")
+	sourceID := fs.String("source-id", "", "source cluster id written to the origin header (default source address)")
+	targetID := fs.String("target-id", "", "messages originating from this id are not mirrored (default target address)")
+	fromBeginning := fs.Bool("from-beginning", true, "start from the earliest offset when the group has no commits")
+	stats := fs.Duration("stats", 0, "print progress to stderr at this interval")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "source topic"); err != nil {
+		return err
+	}
+	if *targetHost == "" {
+		fs.Usage()
+		return fmt.Errorf("missing --target-host")
+	}
+
+	source, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	targetFlags := *g
+	targetFlags.host, targetFlags.port = *targetHost, *targetPort
+	target, err := targetFlags.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+
+	opts := &fluvio.MirrorOptions{
+		SourceTopic:   args[0],
+		Group:         *group,
+		BatchSize:     *batchSize,
+		FlushInterval: *flush,
+		OriginHeader:  *originHeader,
+		SourceID:      *sourceID,
+		TargetID:      *targetID,
+	}
+	if len(args) > 1 {
+		opts.TargetTopic = args[1]
+	}
+	if !*fromBeginning {
+		opts.From = fluvio.OffsetEnd()
+	}
+
+	mirror, err := fluvio.NewMirror(source, target, opts)
+	if err != nil {
+		return err
+	}
+
+	if *stats > 0 {
+		ticker := time.NewTicker(*stats)
+		defer ticker.Stop()
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					s := mirror.Stats()
+					fmt.Fprintf(os.Stderr, "mirrored=%d skipped=%d batches=%d\n", s.Mirrored, s.Skipped, s.Batches)
+				}
+			}
+		}()
+	}
+
+	return mirror.Run(ctx)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+)
+
+// produceSummary 生产结果汇总
+type produceSummary struct {
+	Topic     string `json:"topic"`
+	Produced  int    `json:"produced"`
+	Failed    int    `json:"failed"`
+	Partition int32  `json:"last_partition"`
+	Offset    int64  `json:"last_offset"`
+}
+
+// runProduce 从标准输入或文件读取消息并发送
+// 默认每行一条消息；--raw 时每个输入整体作为一条消息
+func runProduce(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("produce", "produce <topic> [flags]")
+	key := fs.String("key", "", "message key for every message")
+	keySep := fs.String("key-separator", "", "split each line into key and value at the first separator")
+	raw := fs.Bool("raw", false, "send each input (stdin or file) as a single message")
+	batchSize := fs.Int("batch-size", 100, "messages per batch")
+	var files stringsFlag
+	fs.Var(&files, "file", "read messages from file instead of stdin (repeatable)")
+	headers := keyValueFlag{}
+	fs.Var(headers, "header", "message header key=value (repeatable)")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "topic name"); err != nil {
+		return err
+	}
+	if *batchSize <= 0 {
+		return fmt.Errorf("--batch-size must be positive")
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	topic := args[0]
+	summary := &produceSummary{Topic: topic}
+	batch := make([]*fluvio.Message, 0, *batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		result, err := client.Producer().SendBatch(ctx, topic, batch)
+		if result == nil {
+			return err
+		}
+		summary.Produced += result.SuccessCount
+		summary.Failed += result.FailureCount
+		if n := len(result.Results); n > 0 {
+			summary.Partition = result.Results[n-1].Partition
+			summary.Offset = result.Results[n-1].Offset
+		}
+		if err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	emit := func(value []byte) error {
+		msg := &fluvio.Message{Key: *key, Value: value}
+		if *keySep != "" {
+			if k, v, ok := bytes.Cut(value, []byte(*keySep)); ok {
+				msg.Key, msg.Value = string(k), v
+			}
+		}
+		if len(headers) > 0 {
+			msg.Headers = make(map[string]string, len(headers))
+			for k, v := range headers {
+				msg.Headers[k] = v
+			}
+		}
+		batch = append(batch, msg)
+		if len(batch) >= *batchSize {
+			return flush()
+		}
+		return nil
+	}
+
+	readers := []io.Reader{os.Stdin}
+	if len(files) > 0 {
+		readers = readers[:0]
+		for _, name := range files {
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			readers = append(readers, f)
+		}
+	}
+
+	for _, r := range readers {
+		if err := readMessages(r, *raw, emit); err != nil {
+			return err
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"TOPIC", "PRODUCED", "FAILED", "LAST PARTITION", "LAST OFFSET"}}
+	t.add(summary.Topic, summary.Produced, summary.Failed, summary.Partition, summary.Offset)
+	if err := out.print(summary, t); err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d messages failed", summary.Failed)
+	}
+	return nil
+}
+
+// readMessages 从r读取消息，raw为false时按行切分并跳过空行
+func readMessages(r io.Reader, raw bool, emit func([]byte) error) error {
+	if raw {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return emit(data)
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) > 0 {
+			if emitErr := emit(line); emitErr != nil {
+				return emitErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// stringsFlag 可重复的字符串参数
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+)
+
+// runSmartModuleList 列出SmartModule
+func runSmartModuleList(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("smartmodule list", "smartmodule list [flags]")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modules, err := client.Admin().SmartModules().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"NAME", "VERSION", "INPUT", "OUTPUT", "DESCRIPTION"}}
+	for _, m := range modules {
+		t.add(m.Name, m.Version, m.InputKind, m.OutputKind, m.Description)
+	}
+	return out.print(modules, t)
+}
+
+// runSmartModuleDescribe 查看SmartModule规格
+func runSmartModuleDescribe(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("smartmodule describe", "smartmodule describe <name> [flags]")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "smart module name"); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	spec, err := client.Admin().SmartModules().Describe(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"PARAMETER", "OPTIONAL", "DESCRIPTION"}}
+	for _, p := range spec.Parameters {
+		t.add(p.Name, p.Optional, p.Description)
+	}
+
+	if out.format == formatTable {
+		fmt.Printf("Name:        %s\n", spec.Name)
+		fmt.Printf("Version:     %s\n", spec.Version)
+		fmt.Printf("Input:       %s\n", spec.InputKind)
+		fmt.Printf("Output:      %s\n", spec.OutputKind)
+		fmt.Printf("Description: %s\n\n", spec.Description)
+	}
+	return out.print(spec, t)
+}
+
+// runSmartModuleCreate 从.wasm文件创建SmartModule
+func runSmartModuleCreate(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("smartmodule create", "smartmodule create <name> --wasm <file> [flags]")
+	wasm := fs.String("wasm", "", "path to the .wasm file (required)")
+	version := fs.String("version", "", "module version")
+	description := fs.String("description", "", "module description")
+	input := fs.String("input-kind", string(fluvio.SmartModuleStream), "input kind: stream or table")
+	output := fs.String("output-kind", string(fluvio.SmartModuleStream), "output kind: stream or table")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "smart module name"); err != nil {
+		return err
+	}
+	if *wasm == "" {
+		fs.Usage()
+		return fmt.Errorf("--wasm is required")
+	}
+
+	spec := fluvio.NewSmartModuleSpec(args[0]).
+		WithVersion(*version).
+		WithDescription(*description).
+		WithInputKind(fluvio.SmartModuleKind(*input)).
+		WithOutputKind(fluvio.SmartModuleKind(*output))
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Admin().SmartModules().CreateFromFile(ctx, spec, *wasm); err != nil {
+		return err
+	}
+	fmt.Printf("smart module %q created\n", spec.Name)
+	return nil
+}
+
+// runSmartModuleDelete 删除SmartModule
+func runSmartModuleDelete(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("smartmodule delete", "smartmodule delete <name>... [flags]")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "smart module name"); err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	for _, name := range args {
+		if err := client.Admin().SmartModules().Delete(ctx, name); err != nil {
+			return fmt.Errorf("delete %s: %w", name, err)
+		}
+		fmt.Printf("smart module %q deleted\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+	"github.com/iwen-conf/fluvio_grpc_client/domain/entities"
+	"github.com/iwen-conf/fluvio_grpc_client/domain/services"
+	"github.com/iwen-conf/fluvio_grpc_client/domain/valueobjects"
+)
+
+// defaultTailTemplate tail默认输出模板
+const defaultTailTemplate = `{{.Topic}}/{{.Partition}}@{{.Offset}} {{.Timestamp.Format "2006-01-02T15:04:05.000Z07:00"}}{{if .Key}} key={{.Key}}{{end}}{{range $k, $v := .Headers}} {{$k}}={{$v}}{{end}} {{.Value}}`
+
+// filterOperators 过滤表达式中支持的操作符，按长度优先匹配
+var filterOperators = []struct {
+	token    string
+	operator valueobjects.FilterOperator
+}{
+	{"==", valueobjects.FilterOperatorEq},
+	{"!=", valueobjects.FilterOperatorNe},
+	{">=", valueobjects.FilterOperatorGte},
+	{"<=", valueobjects.FilterOperatorLte},
+	{"~=", valueobjects.FilterOperatorRegex},
+	{"*=", valueobjects.FilterOperatorContains},
+	{">", valueobjects.FilterOperatorGt},
+	{"<", valueobjects.FilterOperatorLt},
+}
+
+// runTail 流式跟踪一个或多个主题的所有分区
+func runTail(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("tail", "tail <topic>... [flags]")
+	format := fs.String("format", defaultTailTemplate, "Go text/template for each message (fields: Topic, Partition, Offset, Key, Value, Headers, Timestamp; funcs: json, header)")
+	fromBeginning := fs.Bool("from-beginning", false, "start from the earliest offset")
+	lastN := fs.Int64("n", 0, "start N messages before the end of each partition")
+	since := fs.String("since", "", "start from the first message at or after this time (RFC3339)")
+	var filterExprs stringsFlag
+	fs.Var(&filterExprs, "filter", "filter FIELD OP VALUE, FIELD is key, value, offset or header.NAME, OP is == != > >= < <= ~= (regex) *= (contains) (repeatable)")
+	anyFilter := fs.Bool("any", false, "match messages satisfying any filter instead of all")
+	maxMessages := fs.Int("max", 0, "stop after N matching messages")
+	untilOffset := fs.Int64("until-offset", -1, "stop each partition once this offset is reached")
+	untilTime := fs.String("until-time", "", "stop each partition at the first message after this time (RFC3339)")
+	idle := fs.Duration("idle", 0, "stop when no message arrives for this long")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "topic name"); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	from, err := startSpec(*fromBeginning, -1, *lastN, *since)
+	if err != nil {
+		return err
+	}
+	if from == nil {
+		from = fluvio.OffsetEnd()
+	}
+
+	var until time.Time
+	if *untilTime != "" {
+		if until, err = time.Parse(time.RFC3339, *untilTime); err != nil {
+			return fmt.Errorf("invalid --until-time: %w", err)
+		}
+	}
+
+	messageService := services.NewMessageService()
+	filters, err := parseFilters(messageService, filterExprs)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("tail").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"header": func(headers map[string]string, name string) string {
+			return headers[name]
+		},
+	}).Parse(*format)
+	if err != nil {
+		return fmt.Errorf("invalid --format: %w", err)
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	merged, err := streamTopics(ctx, client, args, from)
+	if err != nil {
+		return err
+	}
+
+	var idleTimer *time.Timer
+	var idleC <-chan time.Time
+	if *idle > 0 {
+		idleTimer = time.NewTimer(*idle)
+		defer idleTimer.Stop()
+		idleC = idleTimer.C
+	}
+
+	w := &recordWriter{out: out, w: os.Stdout}
+	done := make(map[string]bool) // 已满足停止条件的 topic/partition
+	active := len(merged.partitions)
+	count := 0
+
+	for active > 0 {
+		var msg *fluvio.ConsumedMessage
+		select {
+		case m, ok := <-merged.messages:
+			if !ok {
+				return nil
+			}
+			msg = m
+		case <-idleC:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+		if idleTimer != nil {
+			idleTimer.Reset(*idle)
+		}
+
+		id := fmt.Sprintf("%s/%d", msg.Topic, msg.Partition)
+		if done[id] {
+			continue
+		}
+		if (*untilOffset >= 0 && msg.Offset >= *untilOffset) || (!until.IsZero() && msg.Timestamp.After(until)) {
+			done[id] = true
+			active--
+			continue
+		}
+
+		entity := &entities.Message{
+			Key:       msg.Key,
+			Value:     msg.Value,
+			Headers:   msg.Headers,
+			Topic:     msg.Topic,
+			Partition: msg.Partition,
+			Offset:    msg.Offset,
+			Timestamp: msg.Timestamp,
+		}
+		if !messageService.ApplyFilters(entity, filters, !*anyFilter) {
+			continue
+		}
+
+		if out.format == formatTable {
+			if err := writeTemplate(tmpl, msg); err != nil {
+				return err
+			}
+		} else if err := w.write(msg); err != nil {
+			return err
+		}
+
+		count++
+		if *maxMessages > 0 && count >= *maxMessages {
+			return nil
+		}
+	}
+	return nil
+}
+
+// writeTemplate 使用模板输出一条消息
+func writeTemplate(tmpl *template.Template, msg *fluvio.ConsumedMessage) error {
+	var b strings.Builder
+	err := tmpl.Execute(&b, &consumedRecord{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     string(msg.Value),
+		Headers:   msg.Headers,
+		Timestamp: msg.Timestamp,
+	})
+	if err != nil {
+		return err
+	}
+	b.WriteByte('\n')
+	_, err = os.Stdout.WriteString(b.String())
+	return err
+}
+
+// parseFilters 解析过滤表达式
+func parseFilters(ms *services.MessageService, exprs []string) ([]*valueobjects.FilterCondition, error) {
+	filters := make([]*valueobjects.FilterCondition, 0, len(exprs))
+	for _, expr := range exprs {
+		filter, err := parseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		if err := ms.ValidateFilter(filter); err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// parseFilter 解析单个过滤表达式，如 key==order-1、header.source*=api、value~=^ERROR
+func parseFilter(expr string) (*valueobjects.FilterCondition, error) {
+	index, match := -1, -1
+	for i, op := range filterOperators {
+		if pos := strings.Index(expr, op.token); pos > 0 && (index < 0 || pos < index) {
+			index, match = pos, i
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("invalid filter %q: missing operator", expr)
+	}
+
+	field := strings.TrimSpace(expr[:index])
+	value := expr[index+len(filterOperators[match].token):]
+	operator := filterOperators[match].operator
+
+	switch {
+	case field == "key":
+		return valueobjects.NewFilterCondition(valueobjects.FilterTypeKey, operator, value), nil
+	case field == "value":
+		return valueobjects.NewFilterCondition(valueobjects.FilterTypeValue, operator, value), nil
+	case field == "offset":
+		return valueobjects.NewFilterCondition(valueobjects.FilterTypeOffset, operator, value), nil
+	case strings.HasPrefix(field, "header.") && len(field) > len("header."):
+		return valueobjects.NewHeaderFilter(strings.TrimPrefix(field, "header."), operator, value), nil
+	default:
+		return nil, fmt.Errorf("invalid filter %q: unknown field %q", expr, field)
+	}
+}
+
+// mergedStream 多个分区流合并后的消息流
+type mergedStream struct {
+	messages   <-chan *fluvio.ConsumedMessage
+	partitions []string
+}
+
+// streamTopics 为每个主题的每个分区启动Stream，并合并为一个通道
+func streamTopics(ctx context.Context, client *fluvio.Client, topics []string, from *fluvio.OffsetSpec) (*mergedStream, error) {
+	merged := make(chan *fluvio.ConsumedMessage, 100)
+	result := &mergedStream{messages: merged}
+	var wg sync.WaitGroup
+
+	for _, topic := range topics {
+		info, err := client.Topics().Info(ctx, topic)
+		if err != nil {
+			return nil, fmt.Errorf("describe %s: %w", topic, err)
+		}
+		partitions := info.Partitions
+		if partitions <= 0 {
+			partitions = 1
+		}
+
+		for p := int32(0); p < partitions; p++ {
+			partition := p
+			stream, err := client.Consumer().Stream(ctx, topic, &fluvio.StreamOptions{
+				Partition:  &partition,
+				From:       from,
+				BufferSize: 100,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("stream %s/%d: %w", topic, partition, err)
+			}
+			result.partitions = append(result.partitions, fmt.Sprintf("%s/%d", topic, partition))
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for msg := range stream {
+					select {
+					case merged <- msg:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+	return result, nil
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	fluvio "github.com/iwen-conf/fluvio_grpc_client"
+)
+
+// runTopicCreate 创建主题
+func runTopicCreate(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("topic create", "topic create <name> [flags]")
+	partitions := fs.Int("partitions", 1, "number of partitions")
+	replication := fs.Int("replication", 1, "replication factor")
+	retention := fs.Duration("retention", 0, "message retention (0 = unlimited)")
+	ifNotExists := fs.Bool("if-not-exists", false, "do nothing if the topic already exists")
+	config := keyValueFlag{}
+	fs.Var(config, "config", "topic config key=value (repeatable)")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "topic name"); err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	opts := &fluvio.CreateTopicOptions{
+		Partitions:        int32(*partitions),
+		ReplicationFactor: int32(*replication),
+		RetentionMs:       retention.Milliseconds(),
+		Config:            config,
+	}
+
+	name := args[0]
+	if *ifNotExists {
+		created, err := client.Topics().CreateIfNotExists(ctx, name, opts)
+		if err != nil {
+			return err
+		}
+		if !created {
+			fmt.Printf("topic %q already exists\n", name)
+			return nil
+		}
+	} else if err := client.Topics().Create(ctx, name, opts); err != nil {
+		return err
+	}
+
+	fmt.Printf("topic %q created\n", name)
+	return nil
+}
+
+// runTopicList 列出主题
+func runTopicList(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("topic list", "topic list [flags]")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	topics, err := client.Topics().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"NAME"}}
+	for _, name := range topics {
+		t.add(name)
+	}
+	return out.print(topics, t)
+}
+
+// runTopicDescribe 查看主题详情
+func runTopicDescribe(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("topic describe", "topic describe <name> [flags]")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "topic name"); err != nil {
+		return err
+	}
+	out, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	info, err := client.Topics().Info(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"PARTITION", "LEADER", "REPLICAS", "ISR", "LOG START", "HIGH WATERMARK"}}
+	for _, p := range info.PartitionDetails {
+		t.add(p.Partition, p.LeaderID, joinIDs(p.ReplicaIDs), joinIDs(p.ISRIDs), p.LogStartOffset, p.HighWatermark)
+	}
+
+	if out.format == formatTable {
+		fmt.Printf("Name:        %s\n", info.Name)
+		fmt.Printf("Partitions:  %d\n", info.Partitions)
+		fmt.Printf("Replication: %d\n", info.ReplicationFactor)
+		if info.RetentionMs > 0 {
+			fmt.Printf("Retention:   %s\n", info.Retention())
+		}
+		keys := make([]string, 0, len(info.Config))
+		for key := range info.Config {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("Config:      %s=%s\n", key, info.Config[key])
+		}
+		fmt.Println()
+	}
+	return out.print(info, t)
+}
+
+// runTopicDelete 删除主题
+func runTopicDelete(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("topic delete", "topic delete <name>... [flags]")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(fs, args, 1, "topic name"); err != nil {
+		return err
+	}
+
+	client, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	for _, name := range args {
+		if err := client.Topics().Delete(ctx, name); err != nil {
+			return fmt.Errorf("delete %s: %w", name, err)
+		}
+		fmt.Printf("topic %q deleted\n", name)
+	}
+	return nil
+}
+
+// joinIDs 将ID列表格式化为逗号分隔的字符串
+func joinIDs(ids []int32) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprint(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatTime 格式化时间，零值输出 -
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.RFC3339)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
 	"github.com/iwen-conf/fluvio_grpc_client/application/services"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/tracing"
 	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
 )
 
@@ -14,13 +15,16 @@ import (
 type Consumer struct {
 	appService *services.FluvioApplicationService
 	logger     logging.Logger
+	tracer     tracing.Tracer
 	connected  *bool
 }
 
 // ReceiveOptions 接收选项
 type ReceiveOptions struct {
 	Group       string        `json:"group,omitempty"`
+	Partition   *int32        `json:"partition,omitempty"` // 支持指定分区，nil表示使用默认分区0
 	Offset      int64         `json:"offset,omitempty"`
+	From        *OffsetSpec   `json:"-"` // 起始位置规格，设置后覆盖Offset
 	MaxMessages int           `json:"max_messages,omitempty"`
 	Timeout     time.Duration `json:"timeout,omitempty"`
 }
@@ -30,6 +34,7 @@ type StreamOptions struct {
 	Group      string        `json:"group,omitempty"`
 	Partition  *int32        `json:"partition,omitempty"` // 支持指定分区，nil表示使用默认分区0
 	Offset     int64         `json:"offset,omitempty"`
+	From       *OffsetSpec   `json:"-"` // 起始位置规格，设置后覆盖Offset
 	BufferSize int           `json:"buffer_size,omitempty"`
 	Timeout    time.Duration `json:"timeout,omitempty"`
 }
@@ -41,6 +46,43 @@ type ConsumedMessage struct {
 	Partition int32     `json:"partition"`
 	Topic     string    `json:"topic"`
 	Timestamp time.Time `json:"timestamp"`
+
+	trace tracing.SpanContext // 从消息头traceparent/tracestate恢复的上游trace
+}
+
+// Context 返回携带消息trace的ctx，处理消息时以此开始span即可延续生产端的trace
+// 配置了Tracer时为接收该消息的consumer span，否则为消息头中的上游trace
+// 消息没有有效的trace上下文时原样返回parent
+func (m *ConsumedMessage) Context(parent context.Context) context.Context {
+	if !m.trace.IsValid() {
+		return parent
+	}
+	return tracing.ContextWithSpanContext(parent, m.trace)
+}
+
+// TraceContext 获取消息的trace上下文，含义同Context
+func (m *ConsumedMessage) TraceContext() (SpanContext, bool) {
+	return m.trace, m.trace.IsValid()
+}
+
+// traceMessage 为收到的消息记录一个consumer span，父span为消息头中的上游trace，没有时为ctx中的span
+// Tracer创建了新span时，消息的Context延续在该span之下，否则仍为上游trace
+func (c *Consumer) traceMessage(ctx context.Context, msg *ConsumedMessage) {
+	if upstream, ok := tracing.Extract(msg.Headers); ok {
+		msg.trace = upstream
+		ctx = tracing.ContextWithSpanContext(ctx, upstream)
+	}
+	parent, _ := tracing.SpanContextFromContext(ctx)
+	_, span := c.tracer.Start(ctx, "receive "+msg.Topic, tracing.SpanKindConsumer,
+		tracing.Attribute{Key: "messaging.system", Value: "fluvio"},
+		tracing.Attribute{Key: "messaging.destination.name", Value: msg.Topic},
+		tracing.Attribute{Key: "messaging.destination.partition.id", Value: msg.Partition},
+		tracing.Attribute{Key: "messaging.message.id", Value: msg.MessageID},
+		tracing.Attribute{Key: "messaging.fluvio.offset", Value: msg.Offset})
+	if sc := span.SpanContext(); sc.IsValid() && sc != parent {
+		msg.trace = sc
+	}
+	span.End()
 }
 
 // Receive 消费消息
@@ -60,10 +102,25 @@ func (c *Consumer) Receive(ctx context.Context, topic string, opts *ReceiveOptio
 		logging.Field{Key: "group", Value: opts.Group},
 		logging.Field{Key: "max_messages", Value: opts.MaxMessages})
 
+	partition := int32(0)
+	if opts.Partition != nil {
+		partition = *opts.Partition
+	}
+
+	offset := opts.Offset
+	if opts.From != nil {
+		resolved, err := c.ResolveOffset(ctx, topic, partition, opts.From)
+		if err != nil {
+			return nil, err
+		}
+		offset = resolved
+	}
+
 	req := &dtos.ConsumeMessageRequest{
 		Topic:       topic,
 		Group:       opts.Group,
-		Offset:      opts.Offset,
+		Partition:   partition,
+		Offset:      offset,
 		MaxMessages: opts.MaxMessages,
 	}
 
@@ -77,15 +134,18 @@ func (c *Consumer) Receive(ctx context.Context, topic string, opts *ReceiveOptio
 	for _, msg := range resp.Messages {
 		consumedMsg := &ConsumedMessage{
 			Message: &Message{
-				Key:     msg.Key,
-				Value:   []byte(msg.Value),
-				Headers: msg.Headers,
+				Key:       msg.Key,
+				Value:     []byte(msg.Value),
+				Headers:   msg.Headers,
+				Timestamp: msg.Timestamp,
+				MessageID: msg.MessageID,
 			},
 			Offset:    msg.Offset,
 			Partition: msg.Partition,
 			Topic:     topic,
 			Timestamp: msg.Timestamp,
 		}
+		c.traceMessage(ctx, consumedMsg)
 		messages = append(messages, consumedMsg)
 	}
 
@@ -122,7 +182,16 @@ func (c *Consumer) Stream(ctx context.Context, topic string, opts *StreamOptions
 		partition = *opts.Partition
 	}
 
-	appMessageChan, err := c.appService.StreamConsume(ctx, topic, partition, opts.Offset, opts.Group)
+	offset := opts.Offset
+	if opts.From != nil {
+		resolved, err := c.ResolveOffset(ctx, topic, partition, opts.From)
+		if err != nil {
+			return nil, err
+		}
+		offset = resolved
+	}
+
+	appMessageChan, err := c.appService.StreamConsume(ctx, topic, partition, offset, opts.Group)
 	if err != nil {
 		c.logger.Error("Failed to start stream consumption", logging.Field{Key: "error", Value: err})
 		return nil, err
@@ -146,15 +215,18 @@ func (c *Consumer) Stream(ctx context.Context, topic string, opts *StreamOptions
 				// 转换为Consumer API的消息格式
 				consumedMsg := &ConsumedMessage{
 					Message: &Message{
-						Key:     entityMsg.Key,
-						Value:   entityMsg.Value,
-						Headers: entityMsg.Headers,
+						Key:       entityMsg.Key,
+						Value:     entityMsg.Value,
+						Headers:   entityMsg.Headers,
+						Timestamp: entityMsg.Timestamp,
+						MessageID: entityMsg.MessageID,
 					},
 					Topic:     entityMsg.Topic,
 					Partition: entityMsg.Partition,
 					Offset:    entityMsg.Offset,
 					Timestamp: entityMsg.Timestamp,
 				}
+				c.traceMessage(ctx, consumedMsg)
 
 				select {
 				case messageChan <- consumedMsg:
@@ -170,6 +242,19 @@ func (c *Consumer) Stream(ctx context.Context, topic string, opts *StreamOptions
 	return messageChan, nil
 }
 
+// ResolveOffset 将偏移量规格解析为指定分区上的具体偏移量
+func (c *Consumer) ResolveOffset(ctx context.Context, topic string, partition int32, spec *OffsetSpec) (int64, error) {
+	if !*c.connected {
+		return 0, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	resolver := &offsetResolver{
+		appService: c.appService,
+		logger:     c.logger,
+	}
+	return resolver.resolve(ctx, topic, partition, spec)
+}
+
 // Commit 提交偏移量
 func (c *Consumer) Commit(ctx context.Context, topic string, group string, offset int64) error {
 	return c.CommitPartition(ctx, topic, group, 0, offset)
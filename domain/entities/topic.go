@@ -29,6 +29,7 @@ type PartitionInfo struct {
 	PartitionID    int32
 	LeaderID       int32
 	ReplicaIDs     []int32
+	ISRIDs         []int32
 	HighWatermark  int64
 	LowWatermark   int64
 	MessageCount   int64
@@ -23,4 +23,16 @@ type AdminRepository interface {
 	CreateSmartModule(ctx context.Context, req *dtos.CreateSmartModuleRequest) (*dtos.CreateSmartModuleResponse, error)
 	DeleteSmartModule(ctx context.Context, req *dtos.DeleteSmartModuleRequest) (*dtos.DeleteSmartModuleResponse, error)
 	DescribeSmartModule(ctx context.Context, req *dtos.DescribeSmartModuleRequest) (*dtos.DescribeSmartModuleResponse, error)
+	UpdateSmartModule(ctx context.Context, req *dtos.UpdateSmartModuleRequest) (*dtos.UpdateSmartModuleResponse, error)
+
+	// 指标
+	GetMetrics(ctx context.Context, req *dtos.GetMetricsRequest) (*dtos.GetMetricsResponse, error)
+
+	// 存储管理
+	GetStorageStatus(ctx context.Context, req *dtos.GetStorageStatusRequest) (*dtos.GetStorageStatusResponse, error)
+	GetStorageMetrics(ctx context.Context, req *dtos.GetStorageMetricsRequest) (*dtos.GetStorageMetricsResponse, error)
+	MigrateStorage(ctx context.Context, req *dtos.MigrateStorageRequest) (*dtos.MigrateStorageResponse, error)
+
+	// 批量操作
+	BulkDelete(ctx context.Context, req *dtos.BulkDeleteRequest) (*dtos.BulkDeleteResponse, error)
 }
\ No newline at end of file
@@ -12,6 +12,7 @@ type MessageRepository interface {
 	// 生产消息
 	Produce(ctx context.Context, message *entities.Message) error
 	ProduceBatch(ctx context.Context, messages []*entities.Message) error
+	ProduceBatchResults(ctx context.Context, messages []*entities.Message) ([]error, error) // 每条消息一个结果，nil表示成功
 	
 	// 消费消息
 	Consume(ctx context.Context, topic string, partition int32, offset int64, maxMessages int, group string) ([]*entities.Message, error)
@@ -14,6 +14,7 @@ type TopicRepository interface {
 	DeleteTopic(ctx context.Context, req *dtos.DeleteTopicRequest) (*dtos.DeleteTopicResponse, error)
 	ListTopics(ctx context.Context, req *dtos.ListTopicsRequest) (*dtos.ListTopicsResponse, error)
 	DescribeTopic(ctx context.Context, req *dtos.DescribeTopicRequest) (*dtos.DescribeTopicResponse, error)
+	GetTopicStats(ctx context.Context, req *dtos.TopicStatsRequest) (*dtos.TopicStatsResponse, error)
 
 	// 主题管理（实体接口）
 	Create(ctx context.Context, topic *entities.Topic) error
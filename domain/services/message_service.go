@@ -2,13 +2,19 @@ package services
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/iwen-conf/fluvio_grpc_client/domain/entities"
 	"github.com/iwen-conf/fluvio_grpc_client/domain/valueobjects"
 )
 
 // MessageService 消息领域服务
-type MessageService struct{}
+type MessageService struct {
+	regexCache sync.Map // pattern -> *regexp.Regexp
+}
 
 // NewMessageService 创建消息服务
 func NewMessageService() *MessageService {
@@ -82,6 +88,19 @@ func (ms *MessageService) ApplyFilters(message *entities.Message, filters []*val
 	}
 }
 
+// ValidateFilter 验证过滤条件，正则表达式无法编译时返回错误
+func (ms *MessageService) ValidateFilter(filter *valueobjects.FilterCondition) error {
+	if !filter.IsValid() {
+		return fmt.Errorf("invalid filter: %s", filter.String())
+	}
+	if filter.Operator == valueobjects.FilterOperatorRegex {
+		if _, err := ms.compileRegex(filter.Value); err != nil {
+			return fmt.Errorf("invalid regex in filter %s: %w", filter.String(), err)
+		}
+	}
+	return nil
+}
+
 // applyFilter 应用单个过滤条件
 func (ms *MessageService) applyFilter(message *entities.Message, filter *valueobjects.FilterCondition) bool {
 	var targetValue string
@@ -96,6 +115,8 @@ func (ms *MessageService) applyFilter(message *entities.Message, filter *valueob
 			return false
 		}
 		targetValue = message.Headers[filter.Field]
+	case valueobjects.FilterTypeOffset:
+		targetValue = strconv.FormatInt(message.Offset, 10)
 	default:
 		return false
 	}
@@ -104,6 +125,7 @@ func (ms *MessageService) applyFilter(message *entities.Message, filter *valueob
 }
 
 // compareValues 比较值
+// 大小比较在两边都是数字时按数值比较，否则按字符串比较
 func (ms *MessageService) compareValues(target string, operator valueobjects.FilterOperator, expected string) bool {
 	switch operator {
 	case valueobjects.FilterOperatorEq:
@@ -111,27 +133,52 @@ func (ms *MessageService) compareValues(target string, operator valueobjects.Fil
 	case valueobjects.FilterOperatorNe:
 		return target != expected
 	case valueobjects.FilterOperatorContains:
-		return len(target) > 0 && len(expected) > 0 &&
-			target != expected &&
-			(target == expected ||
-				(len(target) > len(expected) &&
-					target[:len(expected)] == expected ||
-					target[len(target)-len(expected):] == expected ||
-					ms.contains(target, expected)))
+		return strings.Contains(target, expected)
+	case valueobjects.FilterOperatorRegex:
+		re, err := ms.compileRegex(expected)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(target)
+	case valueobjects.FilterOperatorGt:
+		return ms.order(target, expected) > 0
+	case valueobjects.FilterOperatorGte:
+		return ms.order(target, expected) >= 0
+	case valueobjects.FilterOperatorLt:
+		return ms.order(target, expected) < 0
+	case valueobjects.FilterOperatorLte:
+		return ms.order(target, expected) <= 0
 	default:
 		return false
 	}
 }
 
-// contains 简单的字符串包含检查
-func (ms *MessageService) contains(s, substr string) bool {
-	if len(substr) > len(s) {
-		return false
+// order 比较两个值的大小
+func (ms *MessageService) order(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
 	}
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compileRegex 编译正则表达式，结果会被缓存以便重复使用
+func (ms *MessageService) compileRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := ms.regexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
 	}
-	return false
+	ms.regexCache.Store(pattern, re)
+	return re, nil
 }
@@ -1,16 +1,31 @@
 package valueobjects
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net"
 	"time"
 )
 
+// 负载均衡策略
+const (
+	LoadBalancingPickFirst  = "pick_first"  // 使用第一个可用地址，失败时切换到下一个
+	LoadBalancingRoundRobin = "round_robin" // 在所有可用地址间轮询
+)
+
 // ConnectionConfig 连接配置值对象
 type ConnectionConfig struct {
 	// 服务器配置
 	Host string
 	Port int
 
+	// 多地址配置，设置后取代Host/Port；每项为host:port，域名会解析为全部地址
+	Endpoints     []string
+	LoadBalancing string // LoadBalancingPickFirst（默认）或LoadBalancingRoundRobin
+
+	// 端点健康检查间隔，大于0时定期对每个地址调用HealthCheck并剔除不健康的地址
+	HealthCheckInterval time.Duration
+
 	// 超时配置
 	ConnectTimeout time.Duration
 	RequestTimeout time.Duration
@@ -27,10 +42,21 @@ type ConnectionConfig struct {
 
 	// TLS配置
 	TLSEnabled bool
-	CertFile   string
+	CertFile   string // 客户端证书，与KeyFile同时设置；都为空时只校验服务端
 	KeyFile    string
-	CAFile     string
-	Insecure   bool // 跳过TLS验证
+	CAFile     string // 为空时使用系统根证书
+	Insecure   bool   // 跳过TLS验证
+
+	// 内存中的PEM，优先于对应的文件
+	CertPEM []byte
+	KeyPEM  []byte
+	CAPEM   []byte
+
+	ServerName string      // 覆盖用于校验证书和SNI的服务器名
+	TLSConfig  *tls.Config // 自定义TLS配置，作为基础配置使用（会被复制）
+
+	// 证书文件检查间隔，大于0时在握手时按间隔检查文件修改时间并重新加载
+	CertReloadInterval time.Duration
 }
 
 // NewConnectionConfig 创建默认连接配置
@@ -80,9 +106,31 @@ func (cc *ConnectionConfig) WithTLS(certFile, keyFile, caFile string) *Connectio
 	return cc
 }
 
+// WithEndpoints 设置多个服务器地址
+func (cc *ConnectionConfig) WithEndpoints(endpoints ...string) *ConnectionConfig {
+	cc.Endpoints = endpoints
+	return cc
+}
+
 // IsValid 验证配置是否有效
 func (cc *ConnectionConfig) IsValid() bool {
-	if cc.Host == "" || cc.Port <= 0 || cc.Port > 65535 {
+	if len(cc.Endpoints) > 0 {
+		for _, endpoint := range cc.Endpoints {
+			if host, port, err := net.SplitHostPort(endpoint); err != nil || host == "" || port == "" {
+				return false
+			}
+		}
+	} else if cc.Host == "" || cc.Port <= 0 || cc.Port > 65535 {
+		return false
+	}
+
+	switch cc.LoadBalancing {
+	case "", LoadBalancingPickFirst, LoadBalancingRoundRobin:
+	default:
+		return false
+	}
+
+	if cc.HealthCheckInterval < 0 {
 		return false
 	}
 
@@ -94,15 +142,35 @@ func (cc *ConnectionConfig) IsValid() bool {
 		return false
 	}
 
-	// 如果启用TLS，检查证书文件
-	if cc.TLSEnabled && (cc.CertFile == "" || cc.KeyFile == "") {
+	// 客户端证书和私钥必须同时设置
+	if cc.TLSEnabled && cc.HasClientCert() != (len(cc.KeyPEM) > 0 || cc.KeyFile != "") {
+		return false
+	}
+
+	if cc.CertReloadInterval < 0 {
 		return false
 	}
 
 	return true
 }
 
-// Address 返回完整的服务器地址
+// HasClientCert 是否配置了客户端证书（文件或PEM）
+func (cc *ConnectionConfig) HasClientCert() bool {
+	return len(cc.CertPEM) > 0 || cc.CertFile != ""
+}
+
+// Address 返回完整的服务器地址，多地址时返回第一个
 func (cc *ConnectionConfig) Address() string {
+	if len(cc.Endpoints) > 0 {
+		return cc.Endpoints[0]
+	}
 	return fmt.Sprintf("%s:%d", cc.Host, cc.Port)
 }
+
+// Addresses 返回所有服务器地址
+func (cc *ConnectionConfig) Addresses() []string {
+	if len(cc.Endpoints) > 0 {
+		return append([]string(nil), cc.Endpoints...)
+	}
+	return []string{cc.Address()}
+}
@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"github.com/iwen-conf/fluvio_grpc_client/application/services"
+	"github.com/iwen-conf/fluvio_grpc_client/domain/valueobjects"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/config"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/grpc"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/metrics"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/repositories"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/tracing"
 	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
 	pb "github.com/iwen-conf/fluvio_grpc_client/proto/fluvio_service"
 )
@@ -21,7 +24,13 @@ type Client struct {
 	grpcClient grpc.Client
 	appService *services.FluvioApplicationService
 	logger     logging.Logger
+	metrics    metrics.Sink
+	tracer     tracing.Tracer
 	connected  bool
+
+	state       *stateTracker
+	probeCancel context.CancelFunc
+	probeDone   chan struct{}
 }
 
 // ClientOption 客户端配置选项函数
@@ -44,16 +53,50 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		return nil, errors.Wrap(errors.ErrInvalidArgument, "invalid configuration", err)
 	}
 
-	// 创建日志器
-	logger := logging.NewDefaultLogger()
-	if cfg.Logging.Level != "" {
-		if level, err := logging.ParseLevel(cfg.Logging.Level); err == nil {
-			logger.SetLevel(level)
+	// 创建日志器，优先使用WithLogger设置的日志器
+	var logger logging.Logger
+	if custom, ok := cfg.Extensions["custom_logger"].(logging.Logger); ok {
+		logger = custom
+	} else {
+		defaultLogger := logging.NewDefaultLogger()
+		if cfg.Logging.Level != "" {
+			if level, err := logging.ParseLevel(cfg.Logging.Level); err == nil {
+				defaultLogger.SetLevel(level)
+			}
+		}
+		logger = defaultLogger
+	}
+
+	// 创建指标接收器，Client.Metrics为false时不记录指标
+	var sink metrics.Sink = metrics.NoopSink{}
+	if cfg.Client.Metrics {
+		if custom, ok := cfg.Extensions["metrics_sink"].(metrics.Sink); ok {
+			sink = custom
+		} else {
+			sink = metrics.NewRegistry()
 		}
 	}
 
 	// 创建连接管理器
 	connManager := grpc.NewConnectionManager(cfg.Connection, logger)
+	connManager.SetUserAgent(cfg.Client.UserAgent)
+	connManager.AddInterceptors(grpc.NewMetadataInterceptors(grpc.MetadataOptions{
+		GenerateRequestID: cfg.Client.RequestID,
+		Headers:           cfg.Client.Headers,
+	}, logger))
+	configureAuth(cfg, connManager, logger)
+	if cfg.Client.Metrics {
+		connManager.SetMetrics(sink)
+	}
+
+	// 创建追踪器，Client.Tracing为false时使用NoopTracer，仅原样传递ctx中已有的trace
+	var tracer tracing.Tracer = tracing.NoopTracer{}
+	if cfg.Client.Tracing {
+		if custom, ok := cfg.Extensions["tracer"].(tracing.Tracer); ok {
+			tracer = custom
+		}
+		connManager.AddInterceptors(grpc.NewTracingInterceptors(tracer))
+	}
 
 	// 创建真实的gRPC客户端
 	grpcClient := grpc.NewDefaultClient(connManager)
@@ -62,17 +105,24 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 	messageRepo := repositories.NewGRPCMessageRepository(grpcClient, logger)
 	topicRepo := repositories.NewGRPCTopicRepository(grpcClient, logger)
 	adminRepo := repositories.NewGRPCAdminRepository(grpcClient, logger)
+	groupRepo := repositories.NewGRPCConsumerGroupRepository(grpcClient, logger)
 
 	// 创建应用服务
 	appService := services.NewFluvioApplicationService(messageRepo, topicRepo, adminRepo, logger)
+	appService.SetConsumerGroupRepository(groupRepo)
 
-	return &Client{
+	client := &Client{
 		config:     cfg,
 		grpcClient: grpcClient,
 		appService: appService,
 		logger:     logger,
+		metrics:    sink,
+		tracer:     tracer,
 		connected:  false,
-	}, nil
+		state:      newStateTracker(logger, connManager.AggregateState),
+	}
+	connManager.SetStateListener(client.state.setConn)
+	return client, nil
 }
 
 // Connect 连接到Fluvio服务器
@@ -82,14 +132,17 @@ func (c *Client) Connect(ctx context.Context) error {
 	}
 
 	c.logger.Info("Connecting to Fluvio server",
-		logging.Field{Key: "host", Value: c.config.Connection.Host},
-		logging.Field{Key: "port", Value: c.config.Connection.Port})
+		logging.Field{Key: "addresses", Value: c.config.Connection.Addresses()})
 
 	if err := c.grpcClient.Connect(); err != nil {
 		return errors.Wrap(errors.ErrConnection, "failed to connect to server", err)
 	}
 
 	c.connected = true
+	c.state.setActive(true)
+	if interval := c.config.Client.HealthProbeInterval; interval > 0 {
+		c.startHealthProbe(interval)
+	}
 	c.logger.Info("Successfully connected to Fluvio server")
 	return nil
 }
@@ -102,12 +155,16 @@ func (c *Client) Close() error {
 
 	c.logger.Info("Closing connection to Fluvio server")
 
-	if err := c.grpcClient.Close(); err != nil {
+	c.stopHealthProbe()
+	// 关闭出错时gRPC客户端同样已不可用，状态一并重置，之后可以重新Connect
+	err := c.grpcClient.Close()
+	c.connected = false
+	c.state.setActive(false)
+	if err != nil {
 		c.logger.Error("Error closing gRPC client", logging.Field{Key: "error", Value: err})
 		return err
 	}
 
-	c.connected = false
 	c.logger.Info("Connection closed successfully")
 	return nil
 }
@@ -182,6 +239,7 @@ func (c *Client) Producer() *Producer {
 	return &Producer{
 		appService: c.appService,
 		logger:     c.logger,
+		tracer:     c.tracer,
 		connected:  &c.connected,
 	}
 }
@@ -191,6 +249,7 @@ func (c *Client) Consumer() *Consumer {
 	return &Consumer{
 		appService: c.appService,
 		logger:     c.logger,
+		tracer:     c.tracer,
 		connected:  &c.connected,
 	}
 }
@@ -209,13 +268,20 @@ func (c *Client) Admin() *AdminManager {
 	return &AdminManager{
 		appService: c.appService,
 		logger:     c.logger,
+		metrics:    c.metrics,
 		connected:  &c.connected,
 	}
 }
 
-// IsConnected 检查是否已连接
+// IsConnected 检查连接是否可用（StateReady或StateIdle）
+// 连接断开或健康探测失败时返回false，恢复后重新返回true
 func (c *Client) IsConnected() bool {
-	return c.connected
+	switch c.State() {
+	case StateReady, StateIdle:
+		return true
+	default:
+		return false
+	}
 }
 
 // Config 获取客户端配置
@@ -228,6 +294,17 @@ func (c *Client) Logger() logging.Logger {
 	return c.logger
 }
 
+// PoolStats 连接池统计信息
+type PoolStats = grpc.Stats
+
+// SubConnStats 连接池中单个子连接的统计信息
+type SubConnStats = grpc.ConnStats
+
+// PoolStats 获取连接池统计信息：子连接数、进行中的调用和流、空闲关闭次数等
+func (c *Client) PoolStats() PoolStats {
+	return c.grpcClient.PoolStats()
+}
+
 // LogLevel 日志级别类型
 type LogLevel string
 
@@ -240,6 +317,15 @@ const (
 	LogLevelFatal LogLevel = "fatal"
 )
 
+// LoadBalancingPolicy 多地址时的负载均衡策略
+type LoadBalancingPolicy string
+
+// 负载均衡策略常量
+const (
+	LoadBalancingPickFirst  LoadBalancingPolicy = valueobjects.LoadBalancingPickFirst
+	LoadBalancingRoundRobin LoadBalancingPolicy = valueobjects.LoadBalancingRoundRobin
+)
+
 // Version 返回SDK版本
 func Version() string {
 	return "2.0.0"
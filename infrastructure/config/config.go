@@ -35,11 +35,14 @@ type LoggingConfig struct {
 
 // ClientConfig 客户端配置
 type ClientConfig struct {
-	UserAgent      string                `json:"user_agent" yaml:"user_agent"`
-	RequestID      bool                  `json:"request_id" yaml:"request_id"`
-	Metrics        bool                  `json:"metrics" yaml:"metrics"`
-	Tracing        bool                  `json:"tracing" yaml:"tracing"`
-	CircuitBreaker *CircuitBreakerConfig `json:"circuit_breaker" yaml:"circuit_breaker"`
+	UserAgent string            `json:"user_agent" yaml:"user_agent"`
+	RequestID bool              `json:"request_id" yaml:"request_id"`
+	Headers   map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // 每次RPC附加的元数据
+	Metrics   bool              `json:"metrics" yaml:"metrics"`
+	Tracing   bool              `json:"tracing" yaml:"tracing"`
+	// 后台健康探测间隔，0表示关闭
+	HealthProbeInterval time.Duration         `json:"health_probe_interval" yaml:"health_probe_interval"`
+	CircuitBreaker      *CircuitBreakerConfig `json:"circuit_breaker" yaml:"circuit_breaker"`
 }
 
 // CircuitBreakerConfig 熔断器配置
@@ -64,10 +67,11 @@ func NewDefaultConfig() *Config {
 			Compress:   true,
 		},
 		Client: &ClientConfig{
-			UserAgent: "fluvio-go-sdk/1.0.0",
-			RequestID: true,
-			Metrics:   false,
-			Tracing:   false,
+			UserAgent:           "fluvio-go-sdk/1.0.0",
+			RequestID:           true,
+			Metrics:             false,
+			Tracing:             false,
+			HealthProbeInterval: 0,
 			CircuitBreaker: &CircuitBreakerConfig{
 				Enabled:          false,
 				FailureThreshold: 5,
@@ -0,0 +1,214 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader API key的元数据键
+const APIKeyHeader = "x-api-key"
+
+// DefaultTokenRefreshBefore 令牌在过期前多久刷新
+const DefaultTokenRefreshBefore = 30 * time.Second
+
+// Token 访问令牌
+type Token struct {
+	AccessToken string
+	TokenType   string    // 默认Bearer
+	Expiry      time.Time // 零值表示不过期
+}
+
+// TokenSource 令牌来源，实现需要支持并发调用
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc 函数形式的TokenSource
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token 实现TokenSource接口
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// CachingTokenSource 缓存令牌，在过期前RefreshBefore时刷新
+type CachingTokenSource struct {
+	source        TokenSource
+	refreshBefore time.Duration
+	logger        logging.Logger
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewCachingTokenSource 创建带缓存的令牌来源，refreshBefore<=0时使用DefaultTokenRefreshBefore
+func NewCachingTokenSource(source TokenSource, refreshBefore time.Duration, logger logging.Logger) *CachingTokenSource {
+	if refreshBefore <= 0 {
+		refreshBefore = DefaultTokenRefreshBefore
+	}
+	return &CachingTokenSource{source: source, refreshBefore: refreshBefore, logger: logger}
+}
+
+// Token 实现TokenSource接口
+// 刷新失败时若旧令牌尚未过期则继续使用旧令牌
+func (c *CachingTokenSource) Token(ctx context.Context) (*Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.token != nil && (c.token.Expiry.IsZero() || now.Add(c.refreshBefore).Before(c.token.Expiry)) {
+		return c.token, nil
+	}
+
+	token, err := c.source.Token(ctx)
+	if err == nil && (token == nil || token.AccessToken == "") {
+		err = errors.New(errors.ErrAuthentication, "token source returned an empty token")
+	}
+	if err != nil {
+		if c.token != nil && (c.token.Expiry.IsZero() || now.Before(c.token.Expiry)) {
+			c.logger.Warn("刷新令牌失败，继续使用未过期的令牌",
+				logging.Field{Key: "expiry", Value: c.token.Expiry},
+				logging.Field{Key: "error", Value: err})
+			return c.token, nil
+		}
+		return nil, err
+	}
+
+	c.logger.Debug("令牌已刷新", logging.Field{Key: "expiry", Value: token.Expiry})
+	c.token = token
+	return token, nil
+}
+
+// Invalidate 丢弃缓存的令牌，下次调用时强制刷新
+func (c *CachingTokenSource) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = nil
+}
+
+// tokenCredentials 以authorization元数据发送令牌
+type tokenCredentials struct {
+	source     TokenSource
+	requireTLS bool
+}
+
+// NewTokenCredentials 创建基于令牌的PerRPCCredentials
+func NewTokenCredentials(source TokenSource, requireTLS bool) credentials.PerRPCCredentials {
+	return &tokenCredentials{source: source, requireTLS: requireTLS}
+}
+
+// GetRequestMetadata 实现credentials.PerRPCCredentials接口
+func (t *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := t.source.Token(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to get token: %v", err)
+	}
+	tokenType := token.TokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+	return map[string]string{"authorization": tokenType + " " + token.AccessToken}, nil
+}
+
+// RequireTransportSecurity 实现credentials.PerRPCCredentials接口
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
+
+// apiKeyCredentials 以x-api-key元数据发送API key
+type apiKeyCredentials struct {
+	key        string
+	requireTLS bool
+}
+
+// NewAPIKeyCredentials 创建基于API key的PerRPCCredentials
+func NewAPIKeyCredentials(key string, requireTLS bool) credentials.PerRPCCredentials {
+	return &apiKeyCredentials{key: key, requireTLS: requireTLS}
+}
+
+// GetRequestMetadata 实现credentials.PerRPCCredentials接口
+func (a *apiKeyCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{APIKeyHeader: a.key}, nil
+}
+
+// RequireTransportSecurity 实现credentials.PerRPCCredentials接口
+func (a *apiKeyCredentials) RequireTransportSecurity() bool {
+	return a.requireTLS
+}
+
+// NewAuthInterceptors 创建认证拦截器
+// 一元调用返回UNAUTHENTICATED时，若source不为nil则强制刷新令牌并重试一次；
+// 流的认证错误通常在首次接收时才出现，此时无法透明重试，只丢弃缓存的令牌，由调用方重新建立的流使用新令牌；
+// 最终的UNAUTHENTICATED错误转换为errors.ErrAuthentication
+func NewAuthInterceptors(source *CachingTokenSource, logger logging.Logger) (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) == codes.Unauthenticated && source != nil {
+			logger.Info("认证失败，刷新令牌后重试", logging.Field{Key: "method", Value: method})
+			source.Invalidate()
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return authError(err)
+	}
+
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if status.Code(err) == codes.Unauthenticated && source != nil {
+			logger.Info("认证失败，刷新令牌后重试", logging.Field{Key: "method", Value: method})
+			source.Invalidate()
+			cs, err = streamer(ctx, desc, cc, method, opts...)
+		}
+		if err != nil {
+			return nil, authError(err)
+		}
+		return &authClientStream{ClientStream: cs, source: source, method: method, logger: logger}, nil
+	}
+
+	return unary, stream
+}
+
+// authClientStream 转换流接收时出现的认证错误
+type authClientStream struct {
+	grpc.ClientStream
+	source *CachingTokenSource
+	method string
+	logger logging.Logger
+}
+
+// Header 实现grpc.ClientStream接口
+func (s *authClientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	return md, s.mapError(err)
+}
+
+// RecvMsg 实现grpc.ClientStream接口
+func (s *authClientStream) RecvMsg(m interface{}) error {
+	return s.mapError(s.ClientStream.RecvMsg(m))
+}
+
+// mapError 遇到UNAUTHENTICATED时丢弃缓存的令牌并转换错误
+func (s *authClientStream) mapError(err error) error {
+	if status.Code(err) == codes.Unauthenticated && s.source != nil {
+		s.logger.Info("流认证失败，已丢弃缓存的令牌", logging.Field{Key: "method", Value: s.method})
+		s.source.Invalidate()
+	}
+	return authError(err)
+}
+
+// authError 将UNAUTHENTICATED转换为ErrAuthentication，其他错误原样返回
+func authError(err error) error {
+	if status.Code(err) != codes.Unauthenticated {
+		return err
+	}
+	return errors.Wrap(errors.ErrAuthentication, "authentication failed", err)
+}
@@ -54,11 +54,13 @@ type Client interface {
 	Connect() error
 	Close() error
 	IsConnected() bool
+	PoolStats() Stats
 }
 
-// DefaultClient 真实的gRPC客户端实现
+// DefaultClient 真实的gRPC客户端实现，调用和流分布在连接池的子连接上
 type DefaultClient struct {
 	connManager *ConnectionManager
+	pool        *ConnectionPool
 	client      pb.FluvioServiceClient
 	adminClient pb.FluvioAdminServiceClient
 	connected   bool
@@ -73,6 +75,14 @@ func NewDefaultClient(connManager *ConnectionManager) *DefaultClient {
 	}
 }
 
+// newPool 创建连接池，Close后再次Connect时重新创建；调用方需持有锁
+func (c *DefaultClient) newPool() *ConnectionPool {
+	if c.pool == nil {
+		c.pool = NewConnectionPoolWithManager(c.connManager)
+	}
+	return c.pool
+}
+
 // Connect 连接到gRPC服务器
 func (c *DefaultClient) Connect() error {
 	c.mu.Lock()
@@ -87,13 +97,18 @@ func (c *DefaultClient) Connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
 	defer cancel()
 
-	conn, err := c.connManager.GetConnection(ctx)
+	// 建立第一个子连接以验证服务器可达，其余子连接按负载按需建立
+	pool := c.newPool()
+	conn, err := pool.Get(ctx)
 	if err != nil {
+		pool.Close()
+		c.pool = nil
 		return fmt.Errorf("failed to get connection: %w", err)
 	}
+	pool.Put(conn)
 
-	c.client = pb.NewFluvioServiceClient(conn)
-	c.adminClient = pb.NewFluvioAdminServiceClient(conn)
+	c.client = pb.NewFluvioServiceClient(pool)
+	c.adminClient = pb.NewFluvioAdminServiceClient(pool)
 	c.connected = true
 	return nil
 }
@@ -110,7 +125,19 @@ func (c *DefaultClient) Close() error {
 	c.connected = false
 	c.client = nil
 	c.adminClient = nil
-	return c.connManager.Close()
+	pool := c.pool
+	c.pool = nil
+	return pool.Close()
+}
+
+// PoolStats 获取连接池统计信息，未连接时返回空统计
+func (c *DefaultClient) PoolStats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.pool == nil {
+		return Stats{PoolSize: c.connManager.GetConfig().PoolSize}
+	}
+	return c.pool.GetStats()
 }
 
 // IsConnected 检查连接状态
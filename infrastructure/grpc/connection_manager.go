@@ -2,13 +2,14 @@ package grpc
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/iwen-conf/fluvio_grpc_client/domain/valueobjects"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/metrics"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/retry"
 	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
 
@@ -25,35 +26,100 @@ type ConnectionManager struct {
 	logger logging.Logger
 	mu     sync.RWMutex
 	conns  map[string]*grpc.ClientConn
+
+	creating map[string]*sync.Mutex // 每个连接编号的创建锁
+
+	stateListener func(connectivity.State)
+	lastState     connectivity.State
+	stateMu       sync.Mutex // 串行化状态通知
+
+	userAgent          string
+	dialOptions        []grpc.DialOption
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	metrics            metrics.Sink
+
+	resolverOnce    sync.Once
+	resolverBuilder *endpointResolverBuilder // 所有连接共用，地址解析和健康检查只做一次
 }
 
 // NewConnectionManager 创建连接管理器
 func NewConnectionManager(config *valueobjects.ConnectionConfig, logger logging.Logger) *ConnectionManager {
 	return &ConnectionManager{
-		config: config,
-		logger: logger,
-		conns:  make(map[string]*grpc.ClientConn),
+		config:    config,
+		logger:    logger,
+		conns:     make(map[string]*grpc.ClientConn),
+		lastState: connectivity.Shutdown,
+	}
+}
+
+// AddInterceptors 添加拦截器，按添加顺序执行；需在建立连接前调用，nil会被忽略
+func (cm *ConnectionManager) AddInterceptors(unary grpc.UnaryClientInterceptor, stream grpc.StreamClientInterceptor) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if unary != nil {
+		cm.unaryInterceptors = append(cm.unaryInterceptors, unary)
+	}
+	if stream != nil {
+		cm.streamInterceptors = append(cm.streamInterceptors, stream)
+	}
+}
+
+// SetUserAgent 设置user-agent，需在建立连接前调用
+func (cm *ConnectionManager) SetUserAgent(userAgent string) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.userAgent = userAgent
+}
+
+// AddDialOptions 添加额外的拨号选项（如PerRPCCredentials），需在建立连接前调用
+func (cm *ConnectionManager) AddDialOptions(opts ...grpc.DialOption) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.dialOptions = append(cm.dialOptions, opts...)
+}
+
+// SetMetrics 设置指标接收器，并添加记录RPC指标的拦截器，连接数指标在连接状态变化时更新；需在建立连接前调用
+func (cm *ConnectionManager) SetMetrics(sink metrics.Sink) {
+	if sink == nil {
+		return
 	}
+	cm.mu.Lock()
+	cm.metrics = sink
+	cm.mu.Unlock()
+	cm.AddInterceptors(NewMetricsInterceptors(sink))
 }
 
 // GetConnection 获取连接
 func (cm *ConnectionManager) GetConnection(ctx context.Context) (*grpc.ClientConn, error) {
-	serverAddr := cm.config.Address()
+	return cm.GetConnectionSlot(ctx, 0)
+}
+
+// GetConnectionSlot 获取指定编号的连接，不同编号对应相互独立的底层连接（供连接池使用）
+// 已有连接只要未关闭就直接返回，TransientFailure由gRPC自行退避重连，
+// 不在这里替换，避免关闭仍被连接池或进行中调用使用的连接
+func (cm *ConnectionManager) GetConnectionSlot(ctx context.Context, slot int) (*grpc.ClientConn, error) {
+	serverAddr := cm.target()
+	key := cm.slotKey(slot)
 
 	cm.mu.RLock()
-	conn, exists := cm.conns[serverAddr]
+	conn, exists := cm.conns[key]
 	cm.mu.RUnlock()
 
-	if exists && cm.isConnectionHealthy(conn) {
+	if exists && cm.isConnectionUsable(conn) {
 		return conn, nil
 	}
 
-	// 需要创建新连接
-	cm.mu.Lock()
-	defer cm.mu.Unlock()
+	// 需要创建新连接，同一编号的创建串行进行，不阻塞其他编号和状态查询
+	createMu := cm.slotMutex(key)
+	createMu.Lock()
+	defer createMu.Unlock()
 
 	// 双重检查
-	if conn, exists := cm.conns[serverAddr]; exists && cm.isConnectionHealthy(conn) {
+	cm.mu.RLock()
+	conn, exists = cm.conns[key]
+	cm.mu.RUnlock()
+	if exists && cm.isConnectionUsable(conn) {
 		return conn, nil
 	}
 
@@ -63,15 +129,140 @@ func (cm *ConnectionManager) GetConnection(ctx context.Context) (*grpc.ClientCon
 		return nil, err
 	}
 
-	// 关闭旧连接（如果存在）
-	if conn != nil {
-		conn.Close()
-	}
+	cm.mu.Lock()
+	cm.conns[key] = newConn
+	cm.mu.Unlock()
+	go cm.watch(newConn)
 
-	cm.conns[serverAddr] = newConn
 	return newConn, nil
 }
 
+// slotMutex 获取连接编号对应的创建锁
+func (cm *ConnectionManager) slotMutex(key string) *sync.Mutex {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.creating == nil {
+		cm.creating = make(map[string]*sync.Mutex)
+	}
+	m, ok := cm.creating[key]
+	if !ok {
+		m = &sync.Mutex{}
+		cm.creating[key] = m
+	}
+	return m
+}
+
+// SetStateListener 设置连接状态监听，所有连接的聚合状态变化时调用；需在建立连接前调用
+func (cm *ConnectionManager) SetStateListener(fn func(connectivity.State)) {
+	cm.stateMu.Lock()
+	defer cm.stateMu.Unlock()
+	cm.stateListener = fn
+}
+
+// AggregateState 所有连接的聚合状态：任一连接就绪即为Ready，其次依次为Idle、Connecting、
+// TransientFailure；没有连接时为Shutdown
+func (cm *ConnectionManager) AggregateState() connectivity.State {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	aggregate := connectivity.Shutdown
+	for _, conn := range cm.conns {
+		state := conn.GetState()
+		if stateRank(state) > stateRank(aggregate) {
+			aggregate = state
+		}
+	}
+	return aggregate
+}
+
+// stateRank 聚合时的优先级
+func stateRank(state connectivity.State) int {
+	switch state {
+	case connectivity.Ready:
+		return 4
+	case connectivity.Idle:
+		return 3
+	case connectivity.Connecting:
+		return 2
+	case connectivity.TransientFailure:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// watch 通过WaitForStateChange跟踪连接状态，连接关闭（Shutdown）后退出
+// 连接断开后由gRPC重新就绪时记为一次重连
+func (cm *ConnectionManager) watch(conn *grpc.ClientConn) {
+	wasReady := false
+	for {
+		state := conn.GetState()
+		cm.notifyState()
+		switch state {
+		case connectivity.Shutdown:
+			return
+		case connectivity.Ready:
+			if wasReady && cm.metrics != nil {
+				cm.metrics.Add(metrics.Reconnects, nil, 1)
+			}
+			wasReady = true
+		}
+		conn.WaitForStateChange(context.Background(), state)
+	}
+}
+
+// notifyState 连接状态变化时更新连接数指标，聚合状态变化时通知监听者
+func (cm *ConnectionManager) notifyState() {
+	cm.stateMu.Lock()
+	defer cm.stateMu.Unlock()
+	if cm.metrics != nil {
+		cm.recordConnections()
+	}
+	if cm.stateListener == nil {
+		return
+	}
+	state := cm.AggregateState()
+	if state == cm.lastState {
+		return
+	}
+	cm.lastState = state
+	cm.stateListener(state)
+}
+
+// recordConnections 按状态更新连接数指标；调用方需持有stateMu，保证按变化顺序写入
+func (cm *ConnectionManager) recordConnections() {
+	counts := make(map[string]int)
+	for _, state := range cm.GetConnectionStates() {
+		counts[state.String()]++
+	}
+	for _, state := range []string{"IDLE", "CONNECTING", "READY", "TRANSIENT_FAILURE", "SHUTDOWN"} {
+		cm.metrics.Set(metrics.Connections, metrics.Labels{"state": state}, float64(counts[state]))
+	}
+}
+
+// CloseConnectionSlot 关闭指定编号的连接
+func (cm *ConnectionManager) CloseConnectionSlot(slot int) error {
+	key := cm.slotKey(slot)
+
+	cm.mu.Lock()
+	conn, exists := cm.conns[key]
+	delete(cm.conns, key)
+	cm.mu.Unlock()
+
+	if !exists {
+		return nil
+	}
+	return conn.Close()
+}
+
+// slotKey 连接编号对应的键，编号0与GetConnection共用
+func (cm *ConnectionManager) slotKey(slot int) string {
+	if slot == 0 {
+		return cm.target()
+	}
+	return fmt.Sprintf("%s#%d", cm.target(), slot)
+}
+
 // createConnection 创建新连接（带重试机制）
 func (cm *ConnectionManager) createConnection(ctx context.Context, serverAddr string) (*grpc.ClientConn, error) {
 	cm.logger.Info("正在创建gRPC连接", logging.Field{Key: "address", Value: serverAddr})
@@ -87,23 +278,23 @@ func (cm *ConnectionManager) createConnection(ctx context.Context, serverAddr st
 	}
 
 	err := retry.Retry(ctx, retryConfig, retry.DefaultIsRetryableError, func() error {
-		opts := []grpc.DialOption{
-			grpc.WithKeepaliveParams(keepalive.ClientParameters{
-				Time:                cm.config.KeepAliveTime,
-				Timeout:             cm.config.KeepAliveTimeout,
-				PermitWithoutStream: true,
-			}),
+		base, err := cm.baseDialOptions()
+		if err != nil {
+			return err
 		}
-
-		// 配置TLS
-		if cm.config.TLSEnabled {
-			creds, err := cm.createTLSCredentials()
-			if err != nil {
-				return errors.Wrap(errors.ErrConnection, "创建TLS凭据失败", err)
-			}
-			opts = append(opts, grpc.WithTransportCredentials(creds))
-		} else {
-			opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		opts := append(append([]grpc.DialOption(nil), base...),
+			grpc.WithChainUnaryInterceptor(cm.unaryInterceptors...),
+			grpc.WithChainStreamInterceptor(cm.streamInterceptors...),
+		)
+		if cm.useEndpointResolver() {
+			// 探测连接不经过拦截器，但保留凭据等基础选项
+			cm.resolverOnce.Do(func() {
+				cm.resolverBuilder = newEndpointResolverBuilder(cm.config, base, cm.logger)
+			})
+			opts = append(opts,
+				grpc.WithResolvers(cm.resolverBuilder),
+				grpc.WithDefaultServiceConfig(loadBalancingServiceConfig(cm.config.LoadBalancing)),
+			)
 		}
 
 		// 创建连接
@@ -133,20 +324,52 @@ func (cm *ConnectionManager) createConnection(ctx context.Context, serverAddr st
 	return conn, nil
 }
 
-// createTLSCredentials 创建TLS凭据
-func (cm *ConnectionManager) createTLSCredentials() (credentials.TransportCredentials, error) {
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false, // 默认安全
+// baseDialOptions 连接的基础拨号选项（保活、user-agent、额外选项和传输凭据），不含拦截器
+func (cm *ConnectionManager) baseDialOptions() ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cm.config.KeepAliveTime,
+			Timeout:             cm.config.KeepAliveTimeout,
+			PermitWithoutStream: true,
+		}),
+	}
+	if cm.userAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(cm.userAgent))
 	}
+	opts = append(opts, cm.dialOptions...)
 
-	if cm.config.CertFile != "" && cm.config.KeyFile != "" {
-		cert, err := tls.LoadX509KeyPair(cm.config.CertFile, cm.config.KeyFile)
+	// 配置TLS
+	if cm.config.TLSEnabled {
+		creds, err := cm.createTLSCredentials()
 		if err != nil {
-			return nil, fmt.Errorf("加载客户端证书失败: %w", err)
+			return nil, errors.Wrap(errors.ErrConnection, "创建TLS凭据失败", err)
 		}
-		tlsConfig.Certificates = []tls.Certificate{cert}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
+	return opts, nil
+}
 
+// useEndpointResolver 是否使用多地址解析器：配置了多个地址或开启了端点健康检查
+func (cm *ConnectionManager) useEndpointResolver() bool {
+	return len(cm.config.Endpoints) > 0 || cm.config.HealthCheckInterval > 0
+}
+
+// target 连接目标，同时作为连接的键
+func (cm *ConnectionManager) target() string {
+	if cm.useEndpointResolver() {
+		return endpointScheme + ":///" + strings.Join(cm.config.Addresses(), ",")
+	}
+	return cm.config.Address()
+}
+
+// createTLSCredentials 创建TLS凭据
+func (cm *ConnectionManager) createTLSCredentials() (credentials.TransportCredentials, error) {
+	tlsConfig, err := buildTLSConfig(cm.config, cm.logger)
+	if err != nil {
+		return nil, err
+	}
 	return credentials.NewTLS(tlsConfig), nil
 }
 
@@ -177,14 +400,9 @@ func (cm *ConnectionManager) waitForConnection(ctx context.Context, conn *grpc.C
 	}
 }
 
-// isConnectionHealthy 检查连接是否健康
-func (cm *ConnectionManager) isConnectionHealthy(conn *grpc.ClientConn) bool {
-	if conn == nil {
-		return false
-	}
-
-	state := conn.GetState()
-	return state == connectivity.Ready || state == connectivity.Idle
+// isConnectionUsable 检查连接是否可以继续使用（未关闭）
+func (cm *ConnectionManager) isConnectionUsable(conn *grpc.ClientConn) bool {
+	return conn != nil && conn.GetState() != connectivity.Shutdown
 }
 
 // Close 关闭所有连接
@@ -203,6 +421,9 @@ func (cm *ConnectionManager) Close() error {
 	}
 
 	cm.conns = make(map[string]*grpc.ClientConn)
+	if cm.resolverBuilder != nil {
+		cm.resolverBuilder.close()
+	}
 	return lastErr
 }
 
@@ -230,7 +451,7 @@ func (cm *ConnectionManager) GetConnectionStates() map[string]connectivity.State
 	return states
 }
 
-// Ping 测试连接
+// Ping 测试连接，只检查编号0连接的状态，不会替换或关闭连接
 func (cm *ConnectionManager) Ping(ctx context.Context) (time.Duration, error) {
 	start := time.Now()
 
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/iwen-conf/fluvio_grpc_client/domain/valueobjects"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
@@ -13,121 +14,334 @@ import (
 )
 
 // ConnectionPool 连接池
+// 最多维护PoolSize个相互独立的子连接，每次调用或流选择进行中调用最少的子连接；
+// 所有子连接都繁忙时在后台建立新的子连接，空闲超过MaxIdleTime的子连接会被关闭（至少保留一个）。
+// 实现grpc.ClientConnInterface，可以直接用于生成的客户端。
 type ConnectionPool struct {
-	config      *valueobjects.ConnectionConfig
-	logger      logging.Logger
-	connMgr     *ConnectionManager
-	pool        chan *grpc.ClientConn
-	mu          sync.RWMutex
-	closed      bool
-	activeConns int
+	config  *valueobjects.ConnectionConfig
+	logger  logging.Logger
+	connMgr *ConnectionManager
+
+	mu      sync.Mutex
+	slots   []*poolSlot
+	closed  bool
+	created uint64
+	evicted uint64
+
+	ctx    context.Context // Close时取消，结束后台任务
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// poolSlot 子连接
+type poolSlot struct {
+	index    int
+	conn     *grpc.ClientConn
+	opening  bool // 正在后台建立连接
+	inFlight int
+	calls    uint64
+	lastUsed time.Time
 }
 
 // NewConnectionPool 创建连接池
 func NewConnectionPool(config *valueobjects.ConnectionConfig, logger logging.Logger) *ConnectionPool {
-	connMgr := NewConnectionManager(config, logger)
+	return NewConnectionPoolWithManager(NewConnectionManager(config, logger))
+}
 
-	return &ConnectionPool{
-		config:  config,
-		logger:  logger,
-		connMgr: connMgr,
-		pool:    make(chan *grpc.ClientConn, config.PoolSize),
+// NewConnectionPoolWithManager 使用已配置好的连接管理器（拦截器、凭据等）创建连接池
+func NewConnectionPoolWithManager(connMgr *ConnectionManager) *ConnectionPool {
+	size := connMgr.config.PoolSize
+	if size <= 0 {
+		size = 1
 	}
-}
 
-// Get 从连接池获取连接
-func (p *ConnectionPool) Get(ctx context.Context) (*grpc.ClientConn, error) {
-	p.mu.RLock()
-	if p.closed {
-		p.mu.RUnlock()
-		return nil, errors.New(errors.ErrConnection, "连接池已关闭")
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &ConnectionPool{
+		config:  connMgr.config,
+		logger:  connMgr.logger,
+		connMgr: connMgr,
+		slots:   make([]*poolSlot, size),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for i := range p.slots {
+		p.slots[i] = &poolSlot{index: i}
 	}
-	p.mu.RUnlock()
 
-	// 尝试从池中获取连接
-	select {
-	case conn := <-p.pool:
-		if p.isConnectionValid(conn) {
-			return conn, nil
-		}
-		// 连接无效，关闭它
-		conn.Close()
-		p.decrementActiveConns()
-	default:
-		// 池中没有可用连接
+	if connMgr.config.MaxIdleTime > 0 && size > 1 {
+		p.wg.Add(1)
+		go p.evictLoop()
 	}
+	return p
+}
 
-	// 创建新连接
-	return p.createNewConnection(ctx)
+// Get 从连接池获取连接，用完后需调用Put归还
+func (p *ConnectionPool) Get(ctx context.Context) (*grpc.ClientConn, error) {
+	slot, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slot.conn, nil
 }
 
-// Put 将连接放回连接池
+// Put 归还Get获取的连接
 func (p *ConnectionPool) Put(conn *grpc.ClientConn) {
 	if conn == nil {
 		return
 	}
 
-	p.mu.RLock()
-	if p.closed {
-		p.mu.RUnlock()
-		conn.Close()
-		p.decrementActiveConns()
-		return
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, slot := range p.slots {
+		if slot.conn == conn && slot.inFlight > 0 {
+			slot.inFlight--
+			slot.lastUsed = time.Now()
+			return
+		}
 	}
-	p.mu.RUnlock()
+}
 
-	if !p.isConnectionValid(conn) {
-		conn.Close()
-		p.decrementActiveConns()
-		return
+// Invoke 实现grpc.ClientConnInterface，在负载最低的子连接上执行一元调用
+func (p *ConnectionPool) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	slot, err := p.acquire(ctx)
+	if err != nil {
+		return err
 	}
+	defer p.release(slot)
+	return slot.conn.Invoke(ctx, method, args, reply, opts...)
+}
 
-	// 尝试放回池中
-	select {
-	case p.pool <- conn:
-		// 成功放回池中
-	default:
-		// 池已满，关闭连接
-		conn.Close()
-		p.decrementActiveConns()
+// NewStream 实现grpc.ClientConnInterface，流在结束或ctx取消前一直占用所在的子连接
+func (p *ConnectionPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	slot, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
 	}
+
+	cs, err := slot.conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		p.release(slot)
+		return nil, err
+	}
+
+	// 流结束（正常、出错或取消）时其ctx都会结束
+	go func() {
+		<-cs.Context().Done()
+		p.release(slot)
+	}()
+	return cs, nil
 }
 
-// createNewConnection 创建新连接
-func (p *ConnectionPool) createNewConnection(ctx context.Context) (*grpc.ClientConn, error) {
+// acquire 选择子连接并增加进行中调用数
+func (p *ConnectionPool) acquire(ctx context.Context) (*poolSlot, error) {
 	p.mu.Lock()
-	if p.activeConns >= p.config.PoolSize {
+	if p.closed {
 		p.mu.Unlock()
-		return nil, errors.New(errors.ErrResourceLimit, "连接池已满")
+		return nil, errors.New(errors.ErrConnection, "连接池已关闭")
 	}
-	p.activeConns++
+
+	best := p.pickLocked()
+	if best != nil {
+		// 所有子连接都繁忙时在后台扩容，本次调用先使用当前负载最低的子连接
+		if best.inFlight > 0 {
+			if next := p.closedSlotLocked(); next != nil {
+				next.opening = true
+				p.wg.Add(1)
+				go p.open(next)
+			}
+		}
+		best.inFlight++
+		best.calls++
+		best.lastUsed = time.Now()
+		p.mu.Unlock()
+		return best, nil
+	}
+
+	// 没有可用的子连接，同步建立
+	slot := p.closedSlotLocked()
+	if slot == nil {
+		// 所有子连接都在后台建立中，使用第一个
+		slot = p.slots[0]
+	}
+	slot.opening = true
+	// 与后台建立一样计入wg，Close会等待建立结束后再关闭连接管理器，新连接不会遗漏
+	p.wg.Add(1)
 	p.mu.Unlock()
 
-	conn, err := p.connMgr.GetConnection(ctx)
+	conn, err := p.dialSync(ctx, slot)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	slot.opening = false
+	if p.closed {
+		return nil, errors.New(errors.ErrConnection, "连接池已关闭")
+	}
 	if err != nil {
-		p.decrementActiveConns()
 		return nil, err
 	}
+	if slot.conn != conn {
+		slot.conn = conn
+		p.created++
+	}
+	slot.inFlight++
+	slot.calls++
+	slot.lastUsed = time.Now()
+	return slot, nil
+}
+
+// dialSync 为调用同步建立子连接，Close时提前取消
+func (p *ConnectionPool) dialSync(ctx context.Context, slot *poolSlot) (*grpc.ClientConn, error) {
+	defer p.wg.Done()
 
-	return conn, nil
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(p.ctx, cancel)
+	defer stop()
+	return p.connMgr.GetConnectionSlot(ctx, slot.index)
 }
 
-// isConnectionValid 检查连接是否有效
-func (p *ConnectionPool) isConnectionValid(conn *grpc.ClientConn) bool {
-	if conn == nil {
-		return false
+// pickLocked 选择进行中调用最少的已建立子连接，优先选择状态正常的；调用方需持有锁
+// 已关闭（Shutdown）的子连接被清空，之后通过GetConnectionSlot重新建立
+func (p *ConnectionPool) pickLocked() *poolSlot {
+	var best, fallback *poolSlot
+	for _, slot := range p.slots {
+		if slot.conn == nil {
+			continue
+		}
+		state := slot.conn.GetState()
+		if state == connectivity.Shutdown {
+			slot.conn = nil
+			continue
+		}
+		if fallback == nil || slot.inFlight < fallback.inFlight {
+			fallback = slot
+		}
+		if state == connectivity.TransientFailure {
+			continue
+		}
+		if best == nil || slot.inFlight < best.inFlight {
+			best = slot
+		}
 	}
+	if best == nil {
+		return fallback
+	}
+	return best
+}
 
-	state := conn.GetState()
-	return state == connectivity.Ready || state == connectivity.Idle
+// closedSlotLocked 返回第一个未建立且不在建立中的子连接；调用方需持有锁
+func (p *ConnectionPool) closedSlotLocked() *poolSlot {
+	for _, slot := range p.slots {
+		if slot.conn == nil && !slot.opening {
+			return slot
+		}
+	}
+	return nil
 }
 
-// decrementActiveConns 减少活跃连接数
-func (p *ConnectionPool) decrementActiveConns() {
+// open 在后台建立子连接
+func (p *ConnectionPool) open(slot *poolSlot) {
+	defer p.wg.Done()
+
+	ctx, cancel := context.WithTimeout(p.ctx, p.config.ConnectTimeout)
+	defer cancel()
+	conn, err := p.connMgr.GetConnectionSlot(ctx, slot.index)
+
 	p.mu.Lock()
-	if p.activeConns > 0 {
-		p.activeConns--
+	defer p.mu.Unlock()
+	slot.opening = false
+	if err != nil {
+		if p.closed {
+			return
+		}
+		p.logger.Warn("建立子连接失败", logging.Field{Key: "slot", Value: slot.index}, logging.Field{Key: "error", Value: err})
+		return
+	}
+	if p.closed {
+		return
+	}
+	if slot.conn != conn {
+		slot.conn = conn
+		slot.lastUsed = time.Now()
+		p.created++
+		p.logger.Debug("子连接已建立", logging.Field{Key: "slot", Value: slot.index})
 	}
+}
+
+// release 减少进行中调用数
+func (p *ConnectionPool) release(slot *poolSlot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if slot.inFlight > 0 {
+		slot.inFlight--
+	}
+	slot.lastUsed = time.Now()
+}
+
+// evictLoop 定期关闭空闲的子连接
+func (p *ConnectionPool) evictLoop() {
+	defer p.wg.Done()
+
+	interval := p.config.MaxIdleTime / 2
+	if interval < time.Second {
+		interval = time.Second
+	} else if interval > time.Minute {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.evictIdle()
+		}
+	}
+}
+
+// evictIdle 关闭空闲超过MaxIdleTime的子连接，至少保留一个已建立的子连接
+func (p *ConnectionPool) evictIdle() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+
+	open := 0
+	for _, slot := range p.slots {
+		if slot.conn != nil {
+			open++
+		}
+	}
+
+	// 从编号大的开始关闭，保留的通常是编号0；关闭期间标记为建立中，避免被重新选中
+	var idle []*poolSlot
+	for i := len(p.slots) - 1; i >= 0 && open > 1; i-- {
+		slot := p.slots[i]
+		if slot.conn == nil || slot.inFlight > 0 || time.Since(slot.lastUsed) < p.config.MaxIdleTime {
+			continue
+		}
+		slot.conn = nil
+		slot.opening = true
+		idle = append(idle, slot)
+		open--
+	}
+	p.mu.Unlock()
+
+	for _, slot := range idle {
+		if err := p.connMgr.CloseConnectionSlot(slot.index); err != nil {
+			p.logger.Debug("关闭空闲子连接失败", logging.Field{Key: "slot", Value: slot.index}, logging.Field{Key: "error", Value: err})
+		}
+		p.logger.Debug("已关闭空闲子连接", logging.Field{Key: "slot", Value: slot.index})
+	}
+
+	p.mu.Lock()
+	for _, slot := range idle {
+		slot.opening = false
+	}
+	p.evicted += uint64(len(idle))
 	p.mu.Unlock()
 }
 
@@ -139,13 +353,13 @@ func (p *ConnectionPool) Close() error {
 		return nil
 	}
 	p.closed = true
+	for _, slot := range p.slots {
+		slot.conn = nil
+	}
 	p.mu.Unlock()
 
-	// 关闭池中的所有连接
-	close(p.pool)
-	for conn := range p.pool {
-		conn.Close()
-	}
+	p.cancel()
+	p.wg.Wait()
 
 	// 关闭连接管理器
 	return p.connMgr.Close()
@@ -153,21 +367,54 @@ func (p *ConnectionPool) Close() error {
 
 // Stats 连接池统计信息
 type Stats struct {
-	PoolSize    int `json:"pool_size"`
-	ActiveConns int `json:"active_conns"`
-	IdleConns   int `json:"idle_conns"`
+	PoolSize    int         `json:"pool_size"`
+	ActiveConns int         `json:"active_conns"` // 已建立的子连接数
+	IdleConns   int         `json:"idle_conns"`   // 没有进行中调用的子连接数
+	InFlight    int         `json:"in_flight"`    // 进行中的调用和流
+	Created     uint64      `json:"created"`      // 累计建立的子连接数
+	Evicted     uint64      `json:"evicted"`      // 因空闲关闭的子连接数
+	Conns       []ConnStats `json:"conns"`
+}
+
+// ConnStats 单个子连接的统计信息
+type ConnStats struct {
+	Index    int           `json:"index"`
+	State    string        `json:"state"`
+	InFlight int           `json:"in_flight"`
+	Calls    uint64        `json:"calls"`
+	IdleFor  time.Duration `json:"idle_for"`
 }
 
 // GetStats 获取连接池统计信息
 func (p *ConnectionPool) GetStats() Stats {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	return Stats{
-		PoolSize:    p.config.PoolSize,
-		ActiveConns: p.activeConns,
-		IdleConns:   len(p.pool),
+	stats := Stats{
+		PoolSize: len(p.slots),
+		Created:  p.created,
+		Evicted:  p.evicted,
+	}
+	for _, slot := range p.slots {
+		if slot.conn == nil {
+			continue
+		}
+		stats.ActiveConns++
+		stats.InFlight += slot.inFlight
+		var idleFor time.Duration
+		if slot.inFlight == 0 {
+			stats.IdleConns++
+			idleFor = time.Since(slot.lastUsed)
+		}
+		stats.Conns = append(stats.Conns, ConnStats{
+			Index:    slot.index,
+			State:    slot.conn.GetState().String(),
+			InFlight: slot.inFlight,
+			Calls:    slot.calls,
+			IdleFor:  idleFor,
+		})
 	}
+	return stats
 }
 
 // PooledConnection 池化连接包装器
@@ -234,4 +481,4 @@ func (f *Factory) Close() error {
 // GetStats 获取统计信息
 func (f *Factory) GetStats() Stats {
 	return f.pool.GetStats()
-}
\ No newline at end of file
+}
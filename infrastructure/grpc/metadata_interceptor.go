@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RequestIDHeader 请求ID的元数据键
+const RequestIDHeader = "x-request-id"
+
+// requestIDKey ctx中请求ID的键
+type requestIDKey struct{}
+
+// ContextWithRequestID 在ctx中设置请求ID，拦截器会优先使用它而不是自动生成
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext 获取ctx中的请求ID
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// NewRequestID 生成请求ID（UUID v4格式）
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// MetadataOptions 每次RPC附加的元数据
+type MetadataOptions struct {
+	GenerateRequestID bool              // ctx中没有请求ID时自动生成
+	Headers           map[string]string // 固定附加的元数据
+}
+
+// RPCError 带请求ID的RPC错误
+// 保留原始gRPC状态，status.Code等函数仍然可用
+type RPCError struct {
+	Method    string
+	RequestID string
+	Err       error
+}
+
+// Error 实现error接口
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%s (request_id=%s)", e.Err, e.RequestID)
+}
+
+// Unwrap 返回原始错误
+func (e *RPCError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus 返回原始gRPC状态
+func (e *RPCError) GRPCStatus() *status.Status {
+	s, _ := status.FromError(e.Err)
+	return s
+}
+
+// GetRequestID 获取请求ID
+func (e *RPCError) GetRequestID() string {
+	return e.RequestID
+}
+
+// NewMetadataInterceptors 创建附加请求ID和固定元数据的拦截器
+// 有请求ID时：写入x-request-id元数据和日志字段；RPC失败时返回*RPCError；
+// 响应中服务端返回的error字段会追加请求ID，便于与服务端日志对应
+func NewMetadataInterceptors(opts MetadataOptions, logger logging.Logger) (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	pairs := make([]string, 0, len(opts.Headers)*2)
+	for k, v := range opts.Headers {
+		pairs = append(pairs, k, v)
+	}
+
+	prepare := func(ctx context.Context) (context.Context, string) {
+		id, ok := RequestIDFromContext(ctx)
+		if !ok && opts.GenerateRequestID {
+			id = NewRequestID()
+			ctx = ContextWithRequestID(ctx, id)
+		}
+		md := pairs
+		if id != "" {
+			md = append(append(make([]string, 0, len(pairs)+2), pairs...), RequestIDHeader, id)
+		}
+		if len(md) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, md...)
+		}
+		return ctx, id
+	}
+
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, id := prepare(ctx)
+		if id == "" {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+
+		start := time.Now()
+		logger.Debug("发送gRPC请求",
+			logging.Field{Key: "method", Value: path.Base(method)},
+			logging.Field{Key: "request_id", Value: id})
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		if err != nil {
+			logger.Warn("gRPC请求失败",
+				logging.Field{Key: "method", Value: path.Base(method)},
+				logging.Field{Key: "request_id", Value: id},
+				logging.Field{Key: "code", Value: status.Code(err).String()},
+				logging.Field{Key: "duration", Value: time.Since(start)},
+				logging.Field{Key: "error", Value: err})
+			return &RPCError{Method: method, RequestID: id, Err: err}
+		}
+
+		if serverErr := annotateReplyError(reply, id); serverErr != "" {
+			logger.Debug("服务端返回错误",
+				logging.Field{Key: "method", Value: path.Base(method)},
+				logging.Field{Key: "request_id", Value: id},
+				logging.Field{Key: "error", Value: serverErr})
+		}
+		return nil
+	}
+
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, id := prepare(ctx)
+		if id != "" {
+			logger.Debug("建立gRPC流",
+				logging.Field{Key: "method", Value: path.Base(method)},
+				logging.Field{Key: "request_id", Value: id})
+		}
+
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil && id != "" {
+			logger.Warn("建立gRPC流失败",
+				logging.Field{Key: "method", Value: path.Base(method)},
+				logging.Field{Key: "request_id", Value: id},
+				logging.Field{Key: "error", Value: err})
+			return nil, &RPCError{Method: method, RequestID: id, Err: err}
+		}
+		return cs, err
+	}
+
+	return unary, stream
+}
+
+// annotateReplyError 在响应的error字段后追加请求ID，返回原始错误信息；响应没有错误时返回空串
+func annotateReplyError(reply interface{}, id string) string {
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return ""
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("error")
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.Cardinality() == protoreflect.Repeated {
+		return ""
+	}
+	value := m.Get(fd).String()
+	if value == "" {
+		return ""
+	}
+	m.Set(fd, protoreflect.ValueOfString(fmt.Sprintf("%s (request_id=%s)", value, id)))
+	return value
+}
@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/metrics"
+	pb "github.com/iwen-conf/fluvio_grpc_client/proto/fluvio_service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// metricsInterceptor 在gRPC调用层记录客户端指标
+type metricsInterceptor struct {
+	sink metrics.Sink
+
+	mu     sync.Mutex
+	failed map[string]bool // 上一条流异常结束的 topic/partition，再次打开时计为重连
+}
+
+// NewMetricsInterceptors 创建记录指标的一元和流式拦截器
+// 记录RPC延迟、按状态码的错误数、生产/消费的消息数和字节数以及流重连次数；
+// 连接数由ConnectionManager在连接状态变化时更新，不在调用路径上统计
+func NewMetricsInterceptors(sink metrics.Sink) (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	m := &metricsInterceptor{sink: sink, failed: make(map[string]bool)}
+	return m.unary, m.stream
+}
+
+// unary 一元调用拦截器
+func (m *metricsInterceptor) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	m.observe(method, start, err)
+	if err == nil {
+		m.recordPayload(req, reply)
+	}
+	return err
+}
+
+// stream 流式调用拦截器
+func (m *metricsInterceptor) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	m.observe(method, start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsClientStream{ClientStream: cs, m: m, method: method}, nil
+}
+
+// observe 记录调用延迟和错误
+func (m *metricsInterceptor) observe(method string, start time.Time, err error) {
+	name := path.Base(method)
+	m.sink.Observe(metrics.RPCDuration, metrics.Labels{"method": name}, time.Since(start).Seconds())
+	if err != nil {
+		m.sink.Add(metrics.RPCErrors, metrics.Labels{"method": name, "code": status.Code(err).String()}, 1)
+	}
+}
+
+// recordPayload 根据请求和响应类型记录生产、消费的消息数和字节数
+func (m *metricsInterceptor) recordPayload(req, reply interface{}) {
+	switch r := req.(type) {
+	case *pb.ProduceRequest:
+		if resp, ok := reply.(*pb.ProduceReply); ok && resp.GetSuccess() {
+			m.produced(r.GetTopic(), 1, len(r.GetMessage()))
+		}
+	case *pb.BatchProduceRequest:
+		resp, ok := reply.(*pb.BatchProduceReply)
+		if !ok {
+			return
+		}
+		count, size := 0, 0
+		for i, success := range resp.GetSuccess() {
+			if success && i < len(r.GetMessages()) {
+				count++
+				size += len(r.GetMessages()[i].GetMessage())
+			}
+		}
+		m.produced(r.GetTopic(), count, size)
+	case *pb.ConsumeRequest:
+		if resp, ok := reply.(*pb.ConsumeReply); ok {
+			m.consumed(r.GetTopic(), resp.GetMessages())
+		}
+	case *pb.FilteredConsumeRequest:
+		if resp, ok := reply.(*pb.FilteredConsumeReply); ok {
+			m.consumed(r.GetTopic(), resp.GetMessages())
+		}
+	}
+}
+
+func (m *metricsInterceptor) produced(topic string, count, size int) {
+	if count == 0 {
+		return
+	}
+	labels := metrics.Labels{"topic": topic}
+	m.sink.Add(metrics.MessagesProduced, labels, float64(count))
+	m.sink.Add(metrics.BytesProduced, labels, float64(size))
+}
+
+func (m *metricsInterceptor) consumed(topic string, messages []*pb.ConsumedMessage) {
+	if len(messages) == 0 {
+		return
+	}
+	size := 0
+	for _, msg := range messages {
+		size += len(msg.GetMessage())
+	}
+	labels := metrics.Labels{"topic": topic}
+	m.sink.Add(metrics.MessagesConsumed, labels, float64(len(messages)))
+	m.sink.Add(metrics.BytesConsumed, labels, float64(size))
+}
+
+// metricsClientStream 统计流式消费的消息
+type metricsClientStream struct {
+	grpc.ClientStream
+	m      *metricsInterceptor
+	method string
+	topic  string
+	key    string // topic/partition
+}
+
+// SendMsg 记录流式消费请求的主题和分区，同一分区上一条流异常结束时计为重连
+func (s *metricsClientStream) SendMsg(msg interface{}) error {
+	if req, ok := msg.(*pb.StreamConsumeRequest); ok {
+		s.topic = req.GetTopic()
+		s.key = fmt.Sprintf("%s/%d", req.GetTopic(), req.GetPartition())
+
+		s.m.mu.Lock()
+		reconnect := s.m.failed[s.key]
+		delete(s.m.failed, s.key)
+		s.m.mu.Unlock()
+		if reconnect {
+			s.m.sink.Add(metrics.StreamReconnects, metrics.Labels{"topic": s.topic}, 1)
+		}
+	}
+	return s.ClientStream.SendMsg(msg)
+}
+
+// RecvMsg 统计收到的消息，流异常结束时记录错误
+func (s *metricsClientStream) RecvMsg(msg interface{}) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err == nil {
+		if consumed, ok := msg.(*pb.ConsumedMessage); ok {
+			s.m.consumed(s.topic, []*pb.ConsumedMessage{consumed})
+		}
+		return nil
+	}
+
+	if err != io.EOF && status.Code(err) != codes.Canceled {
+		s.m.sink.Add(metrics.RPCErrors, metrics.Labels{"method": path.Base(s.method), "code": status.Code(err).String()}, 1)
+		if s.key != "" {
+			s.m.mu.Lock()
+			s.m.failed[s.key] = true
+			s.m.mu.Unlock()
+		}
+	}
+	return err
+}
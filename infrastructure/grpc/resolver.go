@@ -0,0 +1,409 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/domain/valueobjects"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	pb "github.com/iwen-conf/fluvio_grpc_client/proto/fluvio_service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+)
+
+// endpointScheme 多地址解析器的scheme，只通过grpc.WithResolvers注册到单个连接
+const endpointScheme = "fluvio-endpoints"
+
+const (
+	// defaultResolveInterval 未开启健康检查时重新解析域名的间隔
+	defaultResolveInterval = 30 * time.Second
+	// minResolveInterval gRPC请求重新解析（如地址连接失败）时的最小间隔
+	minResolveInterval = time.Second
+	// maxProbeTimeout 单次健康检查的最长等待时间
+	maxProbeTimeout = 5 * time.Second
+)
+
+// loadBalancingServiceConfig 返回指定负载均衡策略的服务配置
+func loadBalancingServiceConfig(policy string) string {
+	if policy == "" {
+		policy = valueobjects.LoadBalancingPickFirst
+	}
+	return fmt.Sprintf(`{"loadBalancingConfig":[{%q:{}}]}`, policy)
+}
+
+// endpointResolverBuilder 多地址解析器构建器
+// 同一连接管理器的所有连接共用一个构建器：由单个goroutine解析地址并执行健康检查，
+// 结果推送给每个连接的解析器，连接池有多个连接时也只探测一次
+type endpointResolverBuilder struct {
+	endpoints  []string
+	interval   time.Duration
+	probeOpts  []grpc.DialOption // 为nil时不做健康检查
+	logger     logging.Logger
+	resolveNow chan struct{}
+
+	mu        sync.Mutex
+	resolvers map[*endpointResolver]struct{}
+	addrs     []resolver.Address // 最近一次的解析结果
+	cancel    context.CancelFunc // 为nil时解析goroutine未运行
+	done      chan struct{}
+}
+
+// newEndpointResolverBuilder 创建多地址解析器构建器
+// HealthCheckInterval大于0时用probeOpts为每个地址建立探测连接并调用HealthCheck
+func newEndpointResolverBuilder(cc *valueobjects.ConnectionConfig, probeOpts []grpc.DialOption, logger logging.Logger) *endpointResolverBuilder {
+	b := &endpointResolverBuilder{
+		endpoints:  cc.Addresses(),
+		interval:   defaultResolveInterval,
+		logger:     logger,
+		resolveNow: make(chan struct{}, 1),
+		resolvers:  make(map[*endpointResolver]struct{}),
+	}
+	if cc.HealthCheckInterval > 0 {
+		b.interval = cc.HealthCheckInterval
+		b.probeOpts = probeOpts
+	}
+	return b
+}
+
+// Build 实现resolver.Builder接口，首次调用时启动解析goroutine
+func (b *endpointResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	r := &endpointResolver{builder: b, cc: cc}
+
+	b.mu.Lock()
+	idle := len(b.resolvers) == 0
+	b.resolvers[r] = struct{}{}
+	addrs := b.addrs
+	if b.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		b.cancel = cancel
+		b.done = make(chan struct{})
+		go b.run(ctx, b.done)
+	}
+	b.mu.Unlock()
+
+	if addrs != nil {
+		// 直接使用已有结果；此前没有连接时结果可能已过期，立即重新解析
+		if err := cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+			b.logger.Debug("更新地址列表失败", logging.Field{Key: "error", Value: err})
+		}
+		if idle {
+			b.trigger()
+		}
+	}
+	return r, nil
+}
+
+// Scheme 实现resolver.Builder接口
+func (b *endpointResolverBuilder) Scheme() string {
+	return endpointScheme
+}
+
+// trigger 请求立即重新解析
+func (b *endpointResolverBuilder) trigger() {
+	select {
+	case b.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+// close 停止解析goroutine并关闭探测连接；之后再次Build会重新启动
+func (b *endpointResolverBuilder) close() {
+	b.mu.Lock()
+	cancel, done := b.cancel, b.done
+	b.cancel, b.done, b.addrs = nil, nil, nil
+	b.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// endpointResolver 单个连接的解析器，地址由构建器统一推送
+type endpointResolver struct {
+	builder *endpointResolverBuilder
+	cc      resolver.ClientConn
+}
+
+// ResolveNow 实现resolver.Resolver接口
+func (r *endpointResolver) ResolveNow(resolver.ResolveNowOptions) {
+	r.builder.trigger()
+}
+
+// Close 实现resolver.Resolver接口
+func (r *endpointResolver) Close() {
+	r.builder.mu.Lock()
+	delete(r.builder.resolvers, r)
+	r.builder.mu.Unlock()
+}
+
+// run 按间隔或在gRPC请求时更新地址
+func (b *endpointResolverBuilder) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	var prober *endpointProber
+	if b.probeOpts != nil {
+		prober = newEndpointProber(b.probeOpts)
+		defer prober.close()
+	}
+	ejected := make(map[string]bool)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-b.resolveNow:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		last := time.Now()
+		b.update(ctx, prober, ejected)
+
+		// 限制重新解析的频率
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(last.Add(minResolveInterval))):
+		}
+		timer.Reset(time.Until(last.Add(b.interval)))
+	}
+}
+
+// update 解析地址、执行健康检查并更新所有连接的地址列表；没有连接时跳过
+func (b *endpointResolverBuilder) update(ctx context.Context, prober *endpointProber, ejected map[string]bool) {
+	if len(b.snapshot()) == 0 {
+		return
+	}
+
+	addrs, err := b.resolve(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			b.logger.Warn("解析服务器地址失败", logging.Field{Key: "error", Value: err})
+			for _, r := range b.snapshot() {
+				r.cc.ReportError(err)
+			}
+		}
+		return
+	}
+
+	if prober != nil {
+		addrs = b.healthy(ctx, prober, ejected, addrs)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+
+	b.mu.Lock()
+	b.addrs = addrs
+	b.mu.Unlock()
+
+	for _, r := range b.snapshot() {
+		if err := r.cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+			b.logger.Debug("更新地址列表失败", logging.Field{Key: "error", Value: err})
+		}
+	}
+}
+
+// snapshot 当前所有连接的解析器
+func (b *endpointResolverBuilder) snapshot() []*endpointResolver {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	list := make([]*endpointResolver, 0, len(b.resolvers))
+	for r := range b.resolvers {
+		list = append(list, r)
+	}
+	return list
+}
+
+// resolve 把所有端点解析为地址，域名解析为全部IP；部分端点解析失败时只使用成功的部分
+func (b *endpointResolverBuilder) resolve(ctx context.Context) ([]resolver.Address, error) {
+	var (
+		addrs   []resolver.Address
+		seen    = make(map[string]bool)
+		lastErr error
+	)
+	for _, endpoint := range b.endpoints {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		ips := []string{host}
+		if net.ParseIP(host) == nil {
+			lookupCtx, cancel := context.WithTimeout(ctx, maxProbeTimeout)
+			ips, err = net.DefaultResolver.LookupHost(lookupCtx, host)
+			cancel()
+			if err != nil {
+				b.logger.Warn("解析域名失败",
+					logging.Field{Key: "endpoint", Value: endpoint},
+					logging.Field{Key: "error", Value: err})
+				lastErr = err
+				continue
+			}
+			sort.Strings(ips)
+		}
+
+		for _, ip := range ips {
+			addr := net.JoinHostPort(ip, port)
+			if seen[addr] {
+				continue
+			}
+			seen[addr] = true
+			// ServerName作为该地址的authority，TLS按原始域名校验证书
+			addrs = append(addrs, resolver.Address{Addr: addr, ServerName: endpoint})
+		}
+	}
+
+	if len(addrs) == 0 {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no endpoints configured")
+		}
+		return nil, fmt.Errorf("no endpoint could be resolved: %w", lastErr)
+	}
+	return addrs, nil
+}
+
+// healthy 并发检查所有地址，返回健康的地址；全部不健康时返回全部地址
+// 剔除和恢复只在状态变化时记录日志
+func (b *endpointResolverBuilder) healthy(ctx context.Context, prober *endpointProber, ejected map[string]bool, addrs []resolver.Address) []resolver.Address {
+	timeout := b.interval
+	if timeout > maxProbeTimeout {
+		timeout = maxProbeTimeout
+	}
+
+	errs := make([]error, len(addrs))
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		wg.Add(1)
+		go func(i int, addr resolver.Address) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			errs[i] = prober.check(probeCtx, addr)
+		}(i, addr)
+	}
+	wg.Wait()
+	prober.retain(addrs)
+
+	healthy := make([]resolver.Address, 0, len(addrs))
+	for i, addr := range addrs {
+		if errs[i] != nil {
+			if !ejected[addr.Addr] {
+				ejected[addr.Addr] = true
+				b.logger.Warn("端点健康检查失败，已剔除",
+					logging.Field{Key: "address", Value: addr.Addr},
+					logging.Field{Key: "error", Value: errs[i]})
+			}
+			continue
+		}
+		if ejected[addr.Addr] {
+			delete(ejected, addr.Addr)
+			b.logger.Info("端点恢复健康", logging.Field{Key: "address", Value: addr.Addr})
+		}
+		healthy = append(healthy, addr)
+	}
+
+	if len(healthy) == 0 {
+		b.logger.Warn("所有端点健康检查均失败，保留全部地址",
+			logging.Field{Key: "addresses", Value: addressList(addrs)})
+		return addrs
+	}
+	return healthy
+}
+
+// addressList 地址列表的字符串形式，用于日志
+func addressList(addrs []resolver.Address) string {
+	list := make([]string, len(addrs))
+	for i, addr := range addrs {
+		list[i] = addr.Addr
+	}
+	return strings.Join(list, ",")
+}
+
+// endpointProber 为每个地址维护一个探测连接并调用HealthCheck
+type endpointProber struct {
+	opts  []grpc.DialOption
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// newEndpointProber 创建探测器
+func newEndpointProber(opts []grpc.DialOption) *endpointProber {
+	return &endpointProber{opts: opts, conns: make(map[string]*grpc.ClientConn)}
+}
+
+// check 调用HealthCheck，UNHEALTHY和UNKNOWN视为失败，DEGRADED视为可用
+func (p *endpointProber) check(ctx context.Context, addr resolver.Address) error {
+	conn, err := p.conn(addr)
+	if err != nil {
+		return err
+	}
+	resp, err := pb.NewFluvioServiceClient(conn).HealthCheck(ctx, &pb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	switch resp.GetStatus() {
+	case pb.HealthStatus_HEALTHY, pb.HealthStatus_DEGRADED:
+		return nil
+	default:
+		return fmt.Errorf("server reported %s: %s", resp.GetStatus(), resp.GetMessage())
+	}
+}
+
+// conn 获取或创建地址的探测连接
+func (p *endpointProber) conn(addr resolver.Address) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if conn, ok := p.conns[addr.Addr]; ok {
+		return conn, nil
+	}
+	opts := append(append([]grpc.DialOption(nil), p.opts...), grpc.WithAuthority(addr.ServerName))
+	conn, err := grpc.NewClient("passthrough:///"+addr.Addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.conns[addr.Addr] = conn
+	return conn, nil
+}
+
+// retain 关闭不在addrs中的探测连接
+func (p *endpointProber) retain(addrs []resolver.Address) {
+	keep := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		keep[addr.Addr] = true
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for addr, conn := range p.conns {
+		if !keep[addr] {
+			conn.Close()
+			delete(p.conns, addr)
+		}
+	}
+}
+
+// close 关闭所有探测连接
+func (p *endpointProber) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for addr, conn := range p.conns {
+		conn.Close()
+		delete(p.conns, addr)
+	}
+}
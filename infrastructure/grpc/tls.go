@@ -0,0 +1,261 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/domain/valueobjects"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+)
+
+// buildTLSConfig 根据连接配置构建TLS配置
+// PEM优先于文件；CertReloadInterval大于0时，证书和CA文件在握手时按间隔检查并重新加载
+func buildTLSConfig(cc *valueobjects.ConnectionConfig, logger logging.Logger) (*tls.Config, error) {
+	var tlsConfig *tls.Config
+	if cc.TLSConfig != nil {
+		tlsConfig = cc.TLSConfig.Clone()
+	} else {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if cc.ServerName != "" {
+		tlsConfig.ServerName = cc.ServerName
+	}
+	if cc.Insecure {
+		logger.Warn("已跳过TLS证书校验，仅应在测试环境使用")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	// 需要从文件热加载的部分
+	var reloader *tlsFileReloader
+	if cc.CertReloadInterval > 0 {
+		certFile, keyFile, caFile := "", "", ""
+		if len(cc.CertPEM) == 0 && cc.CertFile != "" {
+			certFile, keyFile = cc.CertFile, cc.KeyFile
+		}
+		if len(cc.CAPEM) == 0 && cc.CAFile != "" && !tlsConfig.InsecureSkipVerify {
+			caFile = cc.CAFile
+		}
+		if certFile != "" || caFile != "" {
+			var err error
+			reloader, err = newTLSFileReloader(certFile, keyFile, caFile, cc.CertReloadInterval, logger)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 客户端证书
+	switch {
+	case len(cc.CertPEM) > 0:
+		cert, err := tls.X509KeyPair(cc.CertPEM, cc.KeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("解析客户端证书失败: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case reloader != nil && reloader.certFile != "":
+		tlsConfig.Certificates = nil
+		tlsConfig.GetClientCertificate = reloader.clientCertificate
+	case cc.CertFile != "":
+		cert, err := tls.LoadX509KeyPair(cc.CertFile, cc.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载客户端证书失败: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	// 服务端CA，都未设置时使用系统根证书或自定义配置中的RootCAs
+	switch {
+	case len(cc.CAPEM) > 0:
+		pool, err := certPoolFromPEM(cc.CAPEM)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	case reloader != nil && reloader.caFile != "":
+		// CA会变化，不能使用内置校验（它只读取一次RootCAs），改为在VerifyConnection中用当前CA校验
+		// IP地址不会出现在SNI中，此时用配置的服务器名或主机地址校验
+		host := tlsConfig.ServerName
+		if host == "" {
+			host = cc.Host
+		}
+		verify := func(cs tls.ConnectionState) error {
+			return reloader.verifyConnection(cs, host)
+		}
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = chainVerifyConnection(verify, tlsConfig.VerifyConnection)
+	case cc.CAFile != "":
+		data, err := os.ReadFile(cc.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("读取CA证书失败: %w", err)
+		}
+		pool, err := certPoolFromPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// certPoolFromPEM 从PEM数据创建证书池
+func certPoolFromPEM(data []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("CA证书中没有有效的PEM证书")
+	}
+	return pool, nil
+}
+
+// chainVerifyConnection 依次执行两个校验函数，next可以为nil
+func chainVerifyConnection(first, next func(tls.ConnectionState) error) func(tls.ConnectionState) error {
+	if next == nil {
+		return first
+	}
+	return func(cs tls.ConnectionState) error {
+		if err := first(cs); err != nil {
+			return err
+		}
+		return next(cs)
+	}
+}
+
+// tlsFileReloader 按修改时间重新加载证书文件
+// 只在握手时检查，不启动后台goroutine；加载失败时继续使用旧证书，下次检查时重试
+type tlsFileReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	interval time.Duration
+	logger   logging.Logger
+
+	mu      sync.Mutex
+	checked time.Time
+	certMod time.Time
+	caMod   time.Time
+	cert    *tls.Certificate
+	roots   *x509.CertPool
+}
+
+// newTLSFileReloader 创建重新加载器并立即加载一次，首次加载失败时返回错误
+func newTLSFileReloader(certFile, keyFile, caFile string, interval time.Duration, logger logging.Logger) (*tlsFileReloader, error) {
+	r := &tlsFileReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		interval: interval,
+		logger:   logger,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.checked = time.Now()
+	return r, nil
+}
+
+// load 加载修改时间有变化的文件，调用方需持有锁（初始化时除外）
+func (r *tlsFileReloader) load() error {
+	if r.certFile != "" {
+		mod, err := latestModTime(r.certFile, r.keyFile)
+		if err != nil {
+			return fmt.Errorf("检查客户端证书失败: %w", err)
+		}
+		if !mod.Equal(r.certMod) {
+			cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+			if err != nil {
+				return fmt.Errorf("加载客户端证书失败: %w", err)
+			}
+			if r.cert != nil {
+				r.logger.Info("客户端证书已重新加载", logging.Field{Key: "cert_file", Value: r.certFile})
+			}
+			r.cert = &cert
+			r.certMod = mod
+		}
+	}
+
+	if r.caFile != "" {
+		mod, err := latestModTime(r.caFile)
+		if err != nil {
+			return fmt.Errorf("检查CA证书失败: %w", err)
+		}
+		if !mod.Equal(r.caMod) {
+			data, err := os.ReadFile(r.caFile)
+			if err != nil {
+				return fmt.Errorf("读取CA证书失败: %w", err)
+			}
+			pool, err := certPoolFromPEM(data)
+			if err != nil {
+				return err
+			}
+			if r.roots != nil {
+				r.logger.Info("CA证书已重新加载", logging.Field{Key: "ca_file", Value: r.caFile})
+			}
+			r.roots = pool
+			r.caMod = mod
+		}
+	}
+	return nil
+}
+
+// current 距上次检查超过间隔时重新加载，返回当前证书和CA
+func (r *tlsFileReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) >= r.interval {
+		r.checked = time.Now()
+		if err := r.load(); err != nil {
+			r.logger.Warn("重新加载TLS证书失败，继续使用旧证书", logging.Field{Key: "error", Value: err})
+		}
+	}
+	return r.cert, r.roots
+}
+
+// clientCertificate 用于tls.Config.GetClientCertificate
+func (r *tlsFileReloader) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	cert, _ := r.current()
+	return cert, nil
+}
+
+// verifyConnection 用当前CA校验服务端证书链和主机名，握手中没有SNI时使用host
+func (r *tlsFileReloader) verifyConnection(cs tls.ConnectionState, host string) error {
+	_, roots := r.current()
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("服务端未提供证书")
+	}
+
+	name := cs.ServerName
+	if name == "" {
+		name = host
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       name,
+	})
+	return err
+}
+
+// latestModTime 返回多个文件中最新的修改时间
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
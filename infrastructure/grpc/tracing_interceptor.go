@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"path"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/tracing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewTracingInterceptors 创建为每次RPC生成client span的拦截器
+// span的上下文同时以traceparent/tracestate写入请求元数据
+func NewTracingInterceptors(tracer tracing.Tracer) (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startRPCSpan(ctx, tracer, method)
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		recordRPCError(span, err)
+		return err
+	}
+
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startRPCSpan(ctx, tracer, method)
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			recordRPCError(span, err)
+			span.End()
+			return nil, err
+		}
+		return &tracingClientStream{ClientStream: cs, span: span}, nil
+	}
+
+	return unary, stream
+}
+
+// startRPCSpan 开始RPC span并把上下文写入请求元数据
+func startRPCSpan(ctx context.Context, tracer tracing.Tracer, method string) (context.Context, tracing.Span) {
+	ctx, span := tracer.Start(ctx, "fluvio/"+path.Base(method), tracing.SpanKindClient,
+		tracing.Attribute{Key: "rpc.system", Value: "grpc"},
+		tracing.Attribute{Key: "rpc.method", Value: method})
+
+	headers := make(map[string]string, 2)
+	if tracing.Inject(ctx, headers) {
+		pairs := make([]string, 0, 4)
+		for k, v := range headers {
+			pairs = append(pairs, k, v)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+	}
+	return ctx, span
+}
+
+// recordRPCError 记录RPC错误和状态码
+func recordRPCError(span tracing.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(tracing.Attribute{Key: "rpc.grpc.status_code", Value: int(code)})
+	if err != nil {
+		span.RecordError(err)
+	}
+}
+
+// tracingClientStream 在流结束时结束span
+type tracingClientStream struct {
+	grpc.ClientStream
+	span  tracing.Span
+	ended bool
+}
+
+// RecvMsg 流结束（正常或异常）时结束span
+func (s *tracingClientStream) RecvMsg(msg interface{}) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err != nil && !s.ended {
+		s.ended = true
+		if err == io.EOF {
+			recordRPCError(s.span, nil)
+		} else if status.Code(err) == codes.Canceled {
+			s.span.SetAttributes(tracing.Attribute{Key: "rpc.grpc.status_code", Value: int(codes.Canceled)})
+		} else {
+			recordRPCError(s.span, err)
+		}
+		s.span.End()
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+// Package metrics 客户端指标
+//
+// Sink 是指标的写入接口，Registry 是内置实现，按Prometheus文本格式输出，不依赖Prometheus库。
+package metrics
+
+// 客户端指标名称
+const (
+	MessagesProduced = "fluvio_client_messages_produced_total"   // counter: topic
+	BytesProduced    = "fluvio_client_produced_bytes_total"      // counter: topic
+	MessagesConsumed = "fluvio_client_messages_consumed_total"   // counter: topic
+	BytesConsumed    = "fluvio_client_consumed_bytes_total"      // counter: topic
+	RPCDuration      = "fluvio_client_rpc_duration_seconds"      // histogram: method
+	RPCErrors        = "fluvio_client_rpc_errors_total"          // counter: method, code
+	StreamReconnects = "fluvio_client_stream_reconnects_total"   // counter: topic
+	Reconnects       = "fluvio_client_reconnects_total"          // counter: 无标签
+	Connections      = "fluvio_client_connections"               // gauge: state
+	ConsumerLag      = "fluvio_client_consumer_lag"              // gauge: group, topic, partition
+	LagCheckErrors   = "fluvio_client_consumer_lag_errors_total" // counter: group
+)
+
+// Labels 指标标签
+type Labels map[string]string
+
+// Sink 指标接收器
+// 实现需要支持并发调用
+type Sink interface {
+	// Add 累加计数器
+	Add(name string, labels Labels, delta float64)
+	// Set 设置仪表盘当前值
+	Set(name string, labels Labels, value float64)
+	// Observe 向直方图记录一次观测值
+	Observe(name string, labels Labels, value float64)
+}
+
+// NoopSink 丢弃所有指标
+type NoopSink struct{}
+
+// Add 实现Sink接口
+func (NoopSink) Add(string, Labels, float64) {}
+
+// Set 实现Sink接口
+func (NoopSink) Set(string, Labels, float64) {}
+
+// Observe 实现Sink接口
+func (NoopSink) Observe(string, Labels, float64) {}
+
+// help 内置指标的说明
+var help = map[string]string{
+	MessagesProduced: "Messages acknowledged by the server on produce.",
+	BytesProduced:    "Value bytes acknowledged by the server on produce.",
+	MessagesConsumed: "Messages received from consume calls and streams.",
+	BytesConsumed:    "Value bytes received from consume calls and streams.",
+	RPCDuration:      "Client-side gRPC call latency in seconds.",
+	RPCErrors:        "Failed gRPC calls by method and status code.",
+	StreamReconnects: "Consume streams reopened after a stream failure.",
+	Reconnects:       "gRPC connections that became ready again after losing their transport.",
+	Connections:      "gRPC connections held by the client by state.",
+	ConsumerLag:      "Consumer group lag per partition at the last check.",
+	LagCheckErrors:   "Failed consumer lag checks.",
+}
@@ -0,0 +1,298 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets 直方图默认桶（秒）
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// metricType 指标类型
+type metricType string
+
+const (
+	typeCounter   metricType = "counter"
+	typeGauge     metricType = "gauge"
+	typeHistogram metricType = "histogram"
+)
+
+// family 同名指标的所有序列
+type family struct {
+	typ    metricType
+	help   string
+	series map[string]*series
+}
+
+// series 单个标签组合的值
+type series struct {
+	labels  Labels
+	value   float64  // counter、gauge
+	buckets []uint64 // histogram，与Registry.buckets一一对应（非累计）
+	sum     float64  // histogram
+	count   uint64   // histogram
+}
+
+// Registry 内存中的指标注册表，实现Sink和http.Handler
+// 指标类型由首次写入的方法决定，之后以其他方式写入同名指标会被忽略
+type Registry struct {
+	mu       sync.Mutex
+	buckets  []float64
+	families map[string]*family
+}
+
+// NewRegistry 创建注册表，buckets为空时使用DefaultBuckets
+func NewRegistry(buckets ...float64) *Registry {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+	return &Registry{
+		buckets:  b,
+		families: make(map[string]*family),
+	}
+}
+
+// Describe 设置指标说明，用于输出中的 # HELP 行
+func (r *Registry) Describe(name, text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.families[name]; ok {
+		f.help = text
+		return
+	}
+	r.families[name] = &family{help: text, series: make(map[string]*series)}
+}
+
+// Add 实现Sink接口
+func (r *Registry) Add(name string, labels Labels, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s := r.series(name, typeCounter, labels); s != nil {
+		s.value += delta
+	}
+}
+
+// Set 实现Sink接口
+func (r *Registry) Set(name string, labels Labels, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s := r.series(name, typeGauge, labels); s != nil {
+		s.value = value
+	}
+}
+
+// Observe 实现Sink接口
+func (r *Registry) Observe(name string, labels Labels, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.series(name, typeHistogram, labels)
+	if s == nil {
+		return
+	}
+	if s.buckets == nil {
+		s.buckets = make([]uint64, len(r.buckets))
+	}
+	for i, upper := range r.buckets {
+		if value <= upper {
+			s.buckets[i]++
+			break
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// Value 获取计数器或仪表盘的当前值，不存在时返回false
+func (r *Registry) Value(name string, labels Labels) (float64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.families[name]
+	if !ok {
+		return 0, false
+	}
+	s, ok := f.series[labelKey(labels)]
+	if !ok {
+		return 0, false
+	}
+	if f.typ == typeHistogram {
+		return float64(s.count), true
+	}
+	return s.value, true
+}
+
+// series 获取或创建序列，类型不一致时返回nil；调用方需持有锁
+func (r *Registry) series(name string, typ metricType, labels Labels) *series {
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{help: help[name], series: make(map[string]*series)}
+		r.families[name] = f
+	}
+	if f.typ == "" {
+		f.typ = typ
+	}
+	if f.typ != typ {
+		return nil
+	}
+
+	key := labelKey(labels)
+	s, ok := f.series[key]
+	if !ok {
+		copied := make(Labels, len(labels))
+		for k, v := range labels {
+			copied[k] = v
+		}
+		s = &series{labels: copied}
+		f.series[key] = s
+	}
+	return s
+}
+
+// WriteTo 按Prometheus文本格式（0.0.4）输出所有指标
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	names := make([]string, 0, len(r.families))
+	for name, f := range r.families {
+		if f.typ != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := r.families[name]
+		if f.help != "" {
+			fmt.Fprintf(cw, "# HELP %s %s\n", name, escapeHelp(f.help))
+		}
+		fmt.Fprintf(cw, "# TYPE %s %s\n", name, f.typ)
+
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s := f.series[key]
+			if f.typ != typeHistogram {
+				fmt.Fprintf(cw, "%s%s %s\n", name, formatLabels(s.labels, "", ""), formatFloat(s.value))
+				continue
+			}
+
+			var cumulative uint64
+			for i, upper := range r.buckets {
+				if s.buckets != nil {
+					cumulative += s.buckets[i]
+				}
+				fmt.Fprintf(cw, "%s_bucket%s %d\n", name, formatLabels(s.labels, "le", formatFloat(upper)), cumulative)
+			}
+			fmt.Fprintf(cw, "%s_bucket%s %d\n", name, formatLabels(s.labels, "le", "+Inf"), s.count)
+			fmt.Fprintf(cw, "%s_sum%s %s\n", name, formatLabels(s.labels, "", ""), formatFloat(s.sum))
+			fmt.Fprintf(cw, "%s_count%s %d\n", name, formatLabels(s.labels, "", ""), s.count)
+		}
+	}
+
+	if err := cw.w.Flush(); err != nil && cw.err == nil {
+		cw.err = err
+	}
+	return cw.n, cw.err
+}
+
+// ServeHTTP 实现http.Handler，可直接挂载为 /metrics
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := r.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// labelKey 标签的稳定键
+func labelKey(labels Labels) string {
+	return formatLabels(labels, "", "")
+}
+
+// formatLabels 格式化标签，extraKey不为空时追加一个标签（如直方图的le）
+func formatLabels(labels Labels, extraKey, extraValue string) string {
+	if len(labels) == 0 && extraKey == "" {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabel(labels[k]))
+		b.WriteByte('"')
+	}
+	if extraKey != "" {
+		if len(keys) > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(extraKey)
+		b.WriteString(`="`)
+		b.WriteString(extraValue)
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// formatFloat 按Prometheus约定格式化数值
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+
+func escapeHelp(s string) string { return helpEscaper.Replace(s) }
+
+// countingWriter 记录写入字节数和第一个错误
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWriteTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		record func(r *Registry)
+		want   string
+	}{
+		{
+			name:   "empty",
+			record: func(r *Registry) {},
+			want:   "",
+		},
+		{
+			name: "counter with help",
+			record: func(r *Registry) {
+				r.Describe("requests_total", "Requests sent.\nPer topic.")
+				r.Add("requests_total", Labels{"topic": "b"}, 1)
+				r.Add("requests_total", Labels{"topic": "a"}, 2)
+				r.Add("requests_total", Labels{"topic": "a"}, 0.5)
+			},
+			want: `# HELP requests_total Requests sent.\nPer topic.
+# TYPE requests_total counter
+requests_total{topic="a"} 2.5
+requests_total{topic="b"} 1
+`,
+		},
+		{
+			name: "gauge without labels and special values",
+			record: func(r *Registry) {
+				r.Set("temperature", nil, -3)
+				r.Set("temperature", nil, 21.5)
+				r.Set("limit", Labels{"kind": "upper"}, math.Inf(1))
+			},
+			want: `# TYPE limit gauge
+limit{kind="upper"} +Inf
+# TYPE temperature gauge
+temperature 21.5
+`,
+		},
+		{
+			name: "label escaping and ordering",
+			record: func(r *Registry) {
+				r.Set("info", Labels{"z": "1", "path": `C:\tmp`, "msg": "say \"hi\"\nbye"}, 1)
+			},
+			want: `# TYPE info gauge
+info{msg="say \"hi\"\nbye",path="C:\\tmp",z="1"} 1
+`,
+		},
+		{
+			name: "histogram buckets are cumulative",
+			record: func(r *Registry) {
+				r.Observe("latency", Labels{"method": "Produce"}, 0.05)
+				r.Observe("latency", Labels{"method": "Produce"}, 0.1)
+				r.Observe("latency", Labels{"method": "Produce"}, 0.3)
+				r.Observe("latency", Labels{"method": "Produce"}, 2)
+			},
+			want: `# TYPE latency histogram
+latency_bucket{method="Produce",le="0.1"} 2
+latency_bucket{method="Produce",le="0.5"} 3
+latency_bucket{method="Produce",le="+Inf"} 4
+latency_sum{method="Produce"} 2.45
+latency_count{method="Produce"} 4
+`,
+		},
+		{
+			name: "type fixed by first write",
+			record: func(r *Registry) {
+				r.Add("mixed", nil, 1)
+				r.Set("mixed", nil, 10)
+				r.Observe("mixed", nil, 1)
+			},
+			want: `# TYPE mixed counter
+mixed 1
+`,
+		},
+		{
+			name: "described but never written",
+			record: func(r *Registry) {
+				r.Describe("unused", "Never recorded.")
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry(0.5, 0.1)
+			tt.record(r)
+
+			var b strings.Builder
+			n, err := r.WriteTo(&b)
+			if err != nil {
+				t.Fatalf("WriteTo: %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("output mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if n != int64(b.Len()) {
+				t.Errorf("WriteTo returned %d bytes, wrote %d", n, b.Len())
+			}
+		})
+	}
+}
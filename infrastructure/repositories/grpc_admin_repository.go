@@ -8,20 +8,23 @@ import (
 	"github.com/iwen-conf/fluvio_grpc_client/domain/repositories"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/grpc"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/utils"
 	pb "github.com/iwen-conf/fluvio_grpc_client/proto/fluvio_service"
 )
 
 // GRPCAdminRepository gRPC管理仓储实现
 type GRPCAdminRepository struct {
-	client grpc.Client
-	logger logging.Logger
+	client    grpc.Client
+	logger    logging.Logger
+	converter *utils.DTOConverter
 }
 
 // NewGRPCAdminRepository 创建gRPC管理仓储
 func NewGRPCAdminRepository(client grpc.Client, logger logging.Logger) repositories.AdminRepository {
 	return &GRPCAdminRepository{
-		client: client,
-		logger: logger,
+		client:    client,
+		logger:    logger,
+		converter: utils.NewDTOConverter(),
 	}
 }
 
@@ -154,12 +157,23 @@ func (r *GRPCAdminRepository) DescribeConsumerGroup(ctx context.Context, req *dt
 		logging.Field{Key: "group_id", Value: req.GroupID},
 		logging.Field{Key: "offsets_count", Value: len(resp.GetOffsets())})
 
+	// 转换分区位点信息
+	offsets := make([]*dtos.ConsumerGroupOffsetDTO, len(resp.GetOffsets()))
+	for i, offset := range resp.GetOffsets() {
+		offsets[i] = &dtos.ConsumerGroupOffsetDTO{
+			Topic:           offset.GetTopic(),
+			Partition:       offset.GetPartition(),
+			CommittedOffset: offset.GetCommittedOffset(),
+		}
+	}
+
 	// 简化实现：由于protobuf定义中没有成员信息，我们返回空的成员列表
 	return &dtos.DescribeConsumerGroupResponse{
 		Group: &dtos.ConsumerGroupDTO{
 			GroupID: req.GroupID,
 			State:   "Active",                         // 简化实现
 			Members: []*dtos.ConsumerGroupMemberDTO{}, // 空成员列表
+			Offsets: offsets,
 		},
 	}, nil
 }
@@ -186,14 +200,7 @@ func (r *GRPCAdminRepository) ListSmartModules(ctx context.Context, req *dtos.Li
 	}
 
 	// 转换响应
-	modules := make([]*dtos.SmartModuleDTO, len(resp.GetModules()))
-	for i, module := range resp.GetModules() {
-		modules[i] = &dtos.SmartModuleDTO{
-			Name:        module.GetName(),
-			Version:     module.GetVersion(),
-			Description: module.GetDescription(),
-		}
-	}
+	modules := r.converter.ProtoSpecsToSmartModuleDTOs(resp.GetModules())
 
 	r.logger.Debug("列出SmartModule成功", logging.Field{Key: "count", Value: len(modules)})
 
@@ -217,6 +224,9 @@ func (r *GRPCAdminRepository) CreateSmartModule(ctx context.Context, req *dtos.C
 		},
 		WasmCode: req.WasmCode,
 	}
+	if req.Spec != nil {
+		grpcReq.Spec = r.converter.SmartModuleDTOToProtoSpec(req.Spec)
+	}
 
 	// 调用gRPC服务
 	resp, err := r.client.CreateSmartModule(ctx, grpcReq)
@@ -325,10 +335,309 @@ func (r *GRPCAdminRepository) DescribeSmartModule(ctx context.Context, req *dtos
 	r.logger.Debug("描述SmartModule成功", logging.Field{Key: "name", Value: req.Name})
 
 	return &dtos.DescribeSmartModuleResponse{
-		Module: &dtos.SmartModuleDTO{
-			Name:        spec.GetName(),
-			Version:     spec.GetVersion(),
-			Description: spec.GetDescription(),
-		},
+		Module: r.converter.ProtoSpecToSmartModuleDTO(spec),
+	}, nil
+}
+
+// UpdateSmartModule 更新SmartModule
+func (r *GRPCAdminRepository) UpdateSmartModule(ctx context.Context, req *dtos.UpdateSmartModuleRequest) (*dtos.UpdateSmartModuleResponse, error) {
+	r.logger.Debug("Updating SmartModule",
+		logging.Field{Key: "name", Value: req.Name},
+		logging.Field{Key: "update_spec", Value: req.Spec != nil},
+		logging.Field{Key: "wasm_size", Value: len(req.WasmCode)})
+
+	// 构建gRPC请求
+	grpcReq := &pb.UpdateSmartModuleRequest{
+		Name:     req.Name,
+		Spec:     r.converter.SmartModuleDTOToProtoSpec(req.Spec),
+		WasmCode: req.WasmCode,
+	}
+
+	// 调用gRPC服务
+	resp, err := r.client.UpdateSmartModule(ctx, grpcReq)
+	if err != nil {
+		r.logger.Error("更新SmartModule失败",
+			logging.Field{Key: "error", Value: err},
+			logging.Field{Key: "name", Value: req.Name})
+		return nil, fmt.Errorf("failed to update smart module: %w", err)
+	}
+
+	// 检查响应状态
+	if !resp.GetSuccess() {
+		errMsg := resp.GetError()
+		if errMsg == "" {
+			errMsg = "unknown error"
+		}
+		r.logger.Error("SmartModule更新被服务器拒绝",
+			logging.Field{Key: "error", Value: errMsg},
+			logging.Field{Key: "name", Value: req.Name})
+		return &dtos.UpdateSmartModuleResponse{
+			Success: false,
+			Error:   errMsg,
+		}, nil
+	}
+
+	r.logger.Info("SmartModule更新成功", logging.Field{Key: "name", Value: req.Name})
+
+	return &dtos.UpdateSmartModuleResponse{
+		Success: true,
+	}, nil
+}
+
+// BulkDelete 批量删除主题、消费者组和SmartModule
+func (r *GRPCAdminRepository) BulkDelete(ctx context.Context, req *dtos.BulkDeleteRequest) (*dtos.BulkDeleteResponse, error) {
+	r.logger.Debug("Bulk deleting resources",
+		logging.Field{Key: "topics", Value: len(req.Topics)},
+		logging.Field{Key: "consumer_groups", Value: len(req.ConsumerGroups)},
+		logging.Field{Key: "smart_modules", Value: len(req.SmartModules)},
+		logging.Field{Key: "force", Value: req.Force})
+
+	// 构建gRPC请求
+	grpcReq := &pb.BulkDeleteRequest{
+		Topics:         req.Topics,
+		ConsumerGroups: req.ConsumerGroups,
+		SmartModules:   req.SmartModules,
+		Force:          req.Force,
+	}
+
+	// 调用gRPC服务
+	resp, err := r.client.BulkDelete(ctx, grpcReq)
+	if err != nil {
+		r.logger.Error("批量删除失败", logging.Field{Key: "error", Value: err})
+		return nil, fmt.Errorf("failed to bulk delete: %w", err)
+	}
+
+	// 转换响应
+	results := make([]*dtos.BulkDeleteResultDTO, len(resp.GetResults()))
+	for i, result := range resp.GetResults() {
+		results[i] = &dtos.BulkDeleteResultDTO{
+			Name:    result.GetName(),
+			Type:    result.GetType(),
+			Success: result.GetSuccess(),
+			Error:   result.GetError(),
+		}
+	}
+
+	r.logger.Debug("批量删除完成",
+		logging.Field{Key: "successful", Value: resp.GetSuccessfulDeletes()},
+		logging.Field{Key: "failed", Value: resp.GetFailedDeletes()})
+
+	return &dtos.BulkDeleteResponse{
+		Results:           results,
+		TotalRequested:    resp.GetTotalRequested(),
+		SuccessfulDeletes: resp.GetSuccessfulDeletes(),
+		FailedDeletes:     resp.GetFailedDeletes(),
+	}, nil
+}
+
+// GetMetrics 获取集群指标
+func (r *GRPCAdminRepository) GetMetrics(ctx context.Context, req *dtos.GetMetricsRequest) (*dtos.GetMetricsResponse, error) {
+	r.logger.Debug("Getting metrics", logging.Field{Key: "names", Value: req.MetricNames})
+
+	// 构建gRPC请求
+	grpcReq := &pb.GetMetricsRequest{
+		MetricNames: req.MetricNames,
+		Labels:      req.Labels,
+	}
+
+	// 调用gRPC服务
+	resp, err := r.client.GetMetrics(ctx, grpcReq)
+	if err != nil {
+		r.logger.Error("获取指标失败", logging.Field{Key: "error", Value: err})
+		return nil, fmt.Errorf("failed to get metrics: %w", err)
+	}
+
+	// 检查错误
+	if resp.GetError() != "" {
+		return &dtos.GetMetricsResponse{
+			Error: resp.GetError(),
+		}, nil
+	}
+
+	// 转换响应
+	metrics := make([]*dtos.MetricDTO, len(resp.GetMetrics()))
+	for i, metric := range resp.GetMetrics() {
+		metrics[i] = &dtos.MetricDTO{
+			Name:      metric.GetName(),
+			Labels:    metric.GetLabels(),
+			Value:     metric.GetValue(),
+			Timestamp: protoTime(metric.GetTimestamp()),
+		}
+	}
+
+	r.logger.Debug("获取指标成功", logging.Field{Key: "count", Value: len(metrics)})
+
+	return &dtos.GetMetricsResponse{
+		Metrics: metrics,
 	}, nil
 }
+
+// GetStorageStatus 获取存储状态
+func (r *GRPCAdminRepository) GetStorageStatus(ctx context.Context, req *dtos.GetStorageStatusRequest) (*dtos.GetStorageStatusResponse, error) {
+	r.logger.Debug("Getting storage status", logging.Field{Key: "include_details", Value: req.IncludeDetails})
+
+	// 构建gRPC请求
+	grpcReq := &pb.GetStorageStatusRequest{
+		IncludeDetails: req.IncludeDetails,
+	}
+
+	// 调用gRPC服务
+	resp, err := r.client.GetStorageStatus(ctx, grpcReq)
+	if err != nil {
+		r.logger.Error("获取存储状态失败", logging.Field{Key: "error", Value: err})
+		return nil, fmt.Errorf("failed to get storage status: %w", err)
+	}
+
+	// 检查错误
+	if resp.GetError() != "" {
+		return &dtos.GetStorageStatusResponse{
+			Error: resp.GetError(),
+		}, nil
+	}
+
+	result := &dtos.GetStorageStatusResponse{
+		PersistenceEnabled: resp.GetPersistenceEnabled(),
+		CheckedAt:          protoTime(resp.GetCheckedAt()),
+	}
+
+	// 转换存储统计
+	if stats := resp.GetStorageStats(); stats != nil {
+		result.Stats = &dtos.StorageStatsDTO{
+			StorageType:      stats.GetStorageType(),
+			ConsumerGroups:   stats.GetConsumerGroups(),
+			ConsumerOffsets:  stats.GetConsumerOffsets(),
+			SmartModules:     stats.GetSmartModules(),
+			ConnectionStatus: stats.GetConnectionStatus(),
+		}
+		if conn := stats.GetConnectionStats(); conn != nil {
+			result.Stats.ConnectionStats = &dtos.StorageConnectionStatsDTO{
+				CurrentConnections:      conn.GetCurrentConnections(),
+				AvailableConnections:    conn.GetAvailableConnections(),
+				TotalCreatedConnections: conn.GetTotalCreatedConnections(),
+			}
+		}
+		if db := stats.GetDatabaseInfo(); db != nil {
+			result.Stats.DatabaseInfo = &dtos.StorageDatabaseInfoDTO{
+				Name:        db.GetName(),
+				Collections: db.GetCollections(),
+				DataSize:    db.GetDataSize(),
+				StorageSize: db.GetStorageSize(),
+				Indexes:     db.GetIndexes(),
+				IndexSize:   db.GetIndexSize(),
+			}
+		}
+	}
+
+	r.logger.Debug("获取存储状态成功", logging.Field{Key: "persistence_enabled", Value: result.PersistenceEnabled})
+	return result, nil
+}
+
+// GetStorageMetrics 获取存储指标
+func (r *GRPCAdminRepository) GetStorageMetrics(ctx context.Context, req *dtos.GetStorageMetricsRequest) (*dtos.GetStorageMetricsResponse, error) {
+	r.logger.Debug("Getting storage metrics", logging.Field{Key: "include_history", Value: req.IncludeHistory})
+
+	// 构建gRPC请求
+	grpcReq := &pb.GetStorageMetricsRequest{
+		IncludeHistory: req.IncludeHistory,
+		HistoryLimit:   req.HistoryLimit,
+	}
+
+	// 调用gRPC服务
+	resp, err := r.client.GetStorageMetrics(ctx, grpcReq)
+	if err != nil {
+		r.logger.Error("获取存储指标失败", logging.Field{Key: "error", Value: err})
+		return nil, fmt.Errorf("failed to get storage metrics: %w", err)
+	}
+
+	// 检查错误
+	if resp.GetError() != "" {
+		return &dtos.GetStorageMetricsResponse{
+			Error: resp.GetError(),
+		}, nil
+	}
+
+	result := &dtos.GetStorageMetricsResponse{
+		Current:     convertStorageMetrics(resp.GetCurrentMetrics()),
+		History:     make([]*dtos.StorageMetricsDTO, 0, len(resp.GetMetricsHistory())),
+		Alerts:      resp.GetAlerts(),
+		CollectedAt: protoTime(resp.GetCollectedAt()),
+	}
+	for _, metrics := range resp.GetMetricsHistory() {
+		result.History = append(result.History, convertStorageMetrics(metrics))
+	}
+	if health := resp.GetHealthStatus(); health != nil {
+		result.Health = &dtos.StorageHealthCheckDTO{
+			Status:         health.GetStatus(),
+			ResponseTimeMs: health.GetResponseTimeMs(),
+			ErrorMessage:   health.GetErrorMessage(),
+			CheckedAt:      protoTime(health.GetCheckedAt()),
+		}
+	}
+
+	r.logger.Debug("获取存储指标成功",
+		logging.Field{Key: "history_count", Value: len(result.History)},
+		logging.Field{Key: "alerts", Value: len(result.Alerts)})
+	return result, nil
+}
+
+// MigrateStorage 迁移存储
+func (r *GRPCAdminRepository) MigrateStorage(ctx context.Context, req *dtos.MigrateStorageRequest) (*dtos.MigrateStorageResponse, error) {
+	r.logger.Debug("Migrating storage",
+		logging.Field{Key: "source", Value: req.SourceType},
+		logging.Field{Key: "target", Value: req.TargetType},
+		logging.Field{Key: "verify", Value: req.VerifyMigration},
+		logging.Field{Key: "force", Value: req.ForceMigration})
+
+	// 构建gRPC请求
+	grpcReq := &pb.MigrateStorageRequest{
+		SourceType:      req.SourceType,
+		TargetType:      req.TargetType,
+		VerifyMigration: req.VerifyMigration,
+		ForceMigration:  req.ForceMigration,
+	}
+
+	// 调用gRPC服务
+	resp, err := r.client.MigrateStorage(ctx, grpcReq)
+	if err != nil {
+		r.logger.Error("存储迁移失败", logging.Field{Key: "error", Value: err})
+		return nil, fmt.Errorf("failed to migrate storage: %w", err)
+	}
+
+	result := &dtos.MigrateStorageResponse{
+		Success:            resp.GetSuccess(),
+		VerificationPassed: resp.GetVerificationPassed(),
+		CompletedAt:        protoTime(resp.GetCompletedAt()),
+		Error:              resp.GetError(),
+	}
+	if stats := resp.GetMigrationStats(); stats != nil {
+		result.Stats = &dtos.MigrationStatsDTO{
+			ConsumerGroupsMigrated:  stats.GetConsumerGroupsMigrated(),
+			ConsumerOffsetsMigrated: stats.GetConsumerOffsetsMigrated(),
+			SmartModulesMigrated:    stats.GetSmartModulesMigrated(),
+			TotalMigrated:           stats.GetTotalMigrated(),
+			Errors:                  stats.GetErrors(),
+		}
+	}
+
+	r.logger.Debug("存储迁移完成",
+		logging.Field{Key: "success", Value: result.Success},
+		logging.Field{Key: "verification_passed", Value: result.VerificationPassed})
+	return result, nil
+}
+
+// convertStorageMetrics 转换存储指标
+func convertStorageMetrics(metrics *pb.StorageMetricsProto) *dtos.StorageMetricsDTO {
+	if metrics == nil {
+		return nil
+	}
+	return &dtos.StorageMetricsDTO{
+		StorageType:         metrics.GetStorageType(),
+		ResponseTimeMs:      metrics.GetResponseTimeMs(),
+		OperationsPerSecond: metrics.GetOperationsPerSecond(),
+		ErrorRate:           metrics.GetErrorRate(),
+		ConnectionPoolUsage: metrics.GetConnectionPoolUsage(),
+		MemoryUsageMB:       metrics.GetMemoryUsageMb(),
+		DiskUsageMB:         metrics.GetDiskUsageMb(),
+		LastUpdated:         protoTime(metrics.GetLastUpdated()),
+	}
+}
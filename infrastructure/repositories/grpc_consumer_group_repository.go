@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iwen-conf/fluvio_grpc_client/domain/entities"
+	"github.com/iwen-conf/fluvio_grpc_client/domain/repositories"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/grpc"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	pb "github.com/iwen-conf/fluvio_grpc_client/proto/fluvio_service"
+)
+
+// GRPCConsumerGroupRepository gRPC消费组仓储实现
+// 服务端在首次提交偏移量时隐式创建消费组，且不暴露成员管理接口
+type GRPCConsumerGroupRepository struct {
+	client grpc.Client
+	logger logging.Logger
+}
+
+// NewGRPCConsumerGroupRepository 创建gRPC消费组仓储
+func NewGRPCConsumerGroupRepository(client grpc.Client, logger logging.Logger) repositories.ConsumerGroupRepository {
+	return &GRPCConsumerGroupRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Create 创建消费组
+// 服务端没有独立的创建接口，这里通过提交消费组中的初始偏移量完成创建
+func (r *GRPCConsumerGroupRepository) Create(ctx context.Context, group *entities.ConsumerGroup) error {
+	if group == nil || group.GroupID == "" {
+		return fmt.Errorf("consumer group id cannot be empty")
+	}
+	if len(group.Offsets) == 0 {
+		return fmt.Errorf("consumer group %s must have at least one initial offset", group.GroupID)
+	}
+
+	r.logger.Debug("创建消费组",
+		logging.Field{Key: "group_id", Value: group.GroupID},
+		logging.Field{Key: "offsets", Value: len(group.Offsets)})
+
+	exists, err := r.exists(ctx, group.GroupID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("consumer group %s already exists", group.GroupID)
+	}
+
+	for _, offset := range group.Offsets {
+		if err := r.UpdateOffset(ctx, group.GroupID, offset.Topic, offset.Partition, offset.Offset); err != nil {
+			return err
+		}
+	}
+
+	r.logger.Info("消费组创建成功", logging.Field{Key: "group_id", Value: group.GroupID})
+	return nil
+}
+
+// Delete 删除消费组
+func (r *GRPCConsumerGroupRepository) Delete(ctx context.Context, groupID string) error {
+	r.logger.Debug("删除消费组", logging.Field{Key: "group_id", Value: groupID})
+
+	resp, err := r.client.BulkDelete(ctx, &pb.BulkDeleteRequest{
+		ConsumerGroups: []string{groupID},
+	})
+	if err != nil {
+		r.logger.Error("删除消费组失败",
+			logging.Field{Key: "error", Value: err},
+			logging.Field{Key: "group_id", Value: groupID})
+		return fmt.Errorf("failed to delete consumer group: %w", err)
+	}
+
+	for _, result := range resp.GetResults() {
+		if result.GetName() == groupID && !result.GetSuccess() {
+			errMsg := result.GetError()
+			if errMsg == "" {
+				errMsg = "unknown error"
+			}
+			return fmt.Errorf("delete consumer group failed: %s", errMsg)
+		}
+	}
+	if resp.GetFailedDeletes() > 0 {
+		return fmt.Errorf("delete consumer group failed: %s", groupID)
+	}
+
+	r.logger.Info("消费组删除成功", logging.Field{Key: "group_id", Value: groupID})
+	return nil
+}
+
+// List 列出消费组
+// 列表接口只返回组ID，偏移量需通过GetByID获取
+func (r *GRPCConsumerGroupRepository) List(ctx context.Context) ([]*entities.ConsumerGroup, error) {
+	r.logger.Debug("列出消费组")
+
+	resp, err := r.client.ListConsumerGroups(ctx, &pb.ListConsumerGroupsRequest{})
+	if err != nil {
+		r.logger.Error("列出消费组失败", logging.Field{Key: "error", Value: err})
+		return nil, fmt.Errorf("failed to list consumer groups: %w", err)
+	}
+
+	groups := make([]*entities.ConsumerGroup, len(resp.GetGroups()))
+	for i, group := range resp.GetGroups() {
+		// 服务端不返回状态和成员，State保持为空
+		groups[i] = entities.NewConsumerGroup(group.GetGroupId())
+	}
+
+	r.logger.Debug("列出消费组成功", logging.Field{Key: "count", Value: len(groups)})
+	return groups, nil
+}
+
+// GetByID 获取消费组详情
+func (r *GRPCConsumerGroupRepository) GetByID(ctx context.Context, groupID string) (*entities.ConsumerGroup, error) {
+	r.logger.Debug("获取消费组", logging.Field{Key: "group_id", Value: groupID})
+
+	resp, err := r.client.DescribeConsumerGroup(ctx, &pb.DescribeConsumerGroupRequest{
+		GroupId: groupID,
+	})
+	if err != nil {
+		r.logger.Error("获取消费组失败",
+			logging.Field{Key: "error", Value: err},
+			logging.Field{Key: "group_id", Value: groupID})
+		return nil, fmt.Errorf("failed to describe consumer group: %w", err)
+	}
+
+	if resp.GetError() != "" {
+		return nil, fmt.Errorf("describe consumer group failed: %s", resp.GetError())
+	}
+
+	// 服务端不返回状态和成员，State保持为空
+	group := entities.NewConsumerGroup(groupID)
+	for _, offset := range resp.GetOffsets() {
+		group.Offsets = append(group.Offsets, &entities.ConsumerOffset{
+			Topic:     offset.GetTopic(),
+			Partition: offset.GetPartition(),
+			Offset:    offset.GetCommittedOffset(),
+		})
+	}
+
+	r.logger.Debug("获取消费组成功",
+		logging.Field{Key: "group_id", Value: groupID},
+		logging.Field{Key: "offsets_count", Value: len(group.Offsets)})
+	return group, nil
+}
+
+// AddMember 添加成员
+func (r *GRPCConsumerGroupRepository) AddMember(ctx context.Context, groupID string, member *entities.ConsumerMember) error {
+	return fmt.Errorf("consumer group member management is not supported by the gRPC service")
+}
+
+// RemoveMember 移除成员
+func (r *GRPCConsumerGroupRepository) RemoveMember(ctx context.Context, groupID string, memberID string) error {
+	return fmt.Errorf("consumer group member management is not supported by the gRPC service")
+}
+
+// UpdateOffset 更新偏移量
+func (r *GRPCConsumerGroupRepository) UpdateOffset(ctx context.Context, groupID string, topic string, partition int32, offset int64) error {
+	r.logger.Debug("更新消费组偏移量",
+		logging.Field{Key: "group_id", Value: groupID},
+		logging.Field{Key: "topic", Value: topic},
+		logging.Field{Key: "partition", Value: partition},
+		logging.Field{Key: "offset", Value: offset})
+
+	resp, err := r.client.CommitOffset(ctx, &pb.CommitOffsetRequest{
+		Topic:     topic,
+		Partition: partition,
+		Group:     groupID,
+		Offset:    offset,
+	})
+	if err != nil {
+		r.logger.Error("更新消费组偏移量失败",
+			logging.Field{Key: "error", Value: err},
+			logging.Field{Key: "group_id", Value: groupID})
+		return fmt.Errorf("failed to commit offset: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		errMsg := resp.GetError()
+		if errMsg == "" {
+			errMsg = "unknown error"
+		}
+		return fmt.Errorf("commit offset failed: %s", errMsg)
+	}
+
+	return nil
+}
+
+// GetOffsets 获取消费组的所有偏移量
+func (r *GRPCConsumerGroupRepository) GetOffsets(ctx context.Context, groupID string) ([]*entities.ConsumerOffset, error) {
+	group, err := r.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return group.Offsets, nil
+}
+
+// exists 检查消费组是否已存在
+func (r *GRPCConsumerGroupRepository) exists(ctx context.Context, groupID string) (bool, error) {
+	groups, err := r.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, group := range groups {
+		if group.GroupID == groupID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -92,8 +92,28 @@ func (r *GRPCMessageRepository) Produce(ctx context.Context, message *entities.M
 
 // ProduceBatch 批量生产消息
 func (r *GRPCMessageRepository) ProduceBatch(ctx context.Context, messages []*entities.Message) error {
+	results, err := r.ProduceBatchResults(ctx, messages)
+	if err != nil {
+		return err
+	}
+
+	result := utils.NewBatchOperationResult()
+	for _, msgErr := range results {
+		if msgErr != nil {
+			result.AddFailure(msgErr)
+		} else {
+			result.AddSuccess()
+		}
+	}
+
+	// 如果有失败的消息，返回错误
+	return result.GetSummaryError()
+}
+
+// ProduceBatchResults 通过BatchProduce批量生产消息，返回与messages一一对应的结果（nil表示成功）
+func (r *GRPCMessageRepository) ProduceBatchResults(ctx context.Context, messages []*entities.Message) ([]error, error) {
 	if len(messages) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// 记录调试日志
@@ -115,39 +135,41 @@ func (r *GRPCMessageRepository) ProduceBatch(ctx context.Context, messages []*en
 	// 调用gRPC服务
 	resp, err := r.client.BatchProduce(ctx, req)
 	if err != nil {
-		return r.handler.HandleError(err, "批量生产消息", context)
+		return nil, r.handler.HandleError(err, "批量生产消息", context)
 	}
 
 	// 处理批量响应
-	result := utils.NewBatchOperationResult()
+	summary := utils.NewBatchOperationResult()
 	successFlags := resp.GetSuccess()
 	errorMessages := resp.GetError()
+	results := make([]error, len(messages))
 
-	// 处理每个消息的结果
+	// 处理每个消息的结果，服务端未返回结果的消息视为失败
 	for i, message := range messages {
-		if i < len(successFlags) {
-			if successFlags[i] {
-				result.AddSuccess()
-				r.logger.Debug("消息生产成功",
-					logging.Field{Key: "message_id", Value: message.MessageID})
-			} else {
-				errMsg := "unknown error"
-				if i < len(errorMessages) && errorMessages[i] != "" {
-					errMsg = errorMessages[i]
-				}
-				result.AddFailure(fmt.Errorf("message %d failed: %s", i, errMsg))
-				r.logger.Error("消息生产失败",
-					logging.Field{Key: "message_id", Value: message.MessageID},
-					logging.Field{Key: "error", Value: errMsg})
-			}
+		if i < len(successFlags) && successFlags[i] {
+			summary.AddSuccess()
+			r.logger.Debug("消息生产成功",
+				logging.Field{Key: "message_id", Value: message.MessageID})
+			continue
 		}
+
+		errMsg := "unknown error"
+		if i >= len(successFlags) {
+			errMsg = "no result returned by server"
+		} else if i < len(errorMessages) && errorMessages[i] != "" {
+			errMsg = errorMessages[i]
+		}
+		results[i] = fmt.Errorf("message %d failed: %s", i, errMsg)
+		summary.AddFailure(results[i])
+		r.logger.Error("消息生产失败",
+			logging.Field{Key: "message_id", Value: message.MessageID},
+			logging.Field{Key: "error", Value: errMsg})
 	}
 
 	// 记录汇总日志
-	result.LogSummary(r.handler, "批量消息生产", context)
+	summary.LogSummary(r.handler, "批量消息生产", context)
 
-	// 如果有失败的消息，返回错误
-	return result.GetSummaryError()
+	return results, nil
 }
 
 // Consume 消费消息
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
 	"github.com/iwen-conf/fluvio_grpc_client/domain/entities"
@@ -10,6 +11,7 @@ import (
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/grpc"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
 	pb "github.com/iwen-conf/fluvio_grpc_client/proto/fluvio_service"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // GRPCTopicRepository gRPC主题仓储实现
@@ -35,6 +37,7 @@ func (r *GRPCTopicRepository) CreateTopic(ctx context.Context, req *dtos.CreateT
 		Topic:             req.Name,
 		Partitions:        req.Partitions,
 		ReplicationFactor: req.ReplicationFactor,
+		RetentionMs:       req.RetentionMs,
 		Config:            req.Config,
 	}
 
@@ -157,11 +160,16 @@ func (r *GRPCTopicRepository) DescribeTopic(ctx context.Context, req *dtos.Descr
 
 	r.logger.Debug("描述主题成功", logging.Field{Key: "topic", Value: req.Name})
 
+	partitions := convertPartitionInfos(resp.GetPartitions())
+
 	return &dtos.DescribeTopicResponse{
 		Topic: &dtos.TopicDTO{
-			Name:       resp.GetTopic(),
-			Partitions: int32(len(resp.GetPartitions())), // 从分区列表计算分区数
-			Config:     resp.GetConfig(),
+			Name:              resp.GetTopic(),
+			Partitions:        int32(len(resp.GetPartitions())), // 从分区列表计算分区数
+			ReplicationFactor: replicationFactorOf(partitions),
+			RetentionMs:       resp.GetRetentionMs(),
+			Config:            resp.GetConfig(),
+			PartitionDetails:  partitions,
 		},
 	}, nil
 }
@@ -177,6 +185,7 @@ func (r *GRPCTopicRepository) Create(ctx context.Context, topic *entities.Topic)
 		Topic:             topic.Name,
 		Partitions:        topic.Partitions,
 		ReplicationFactor: topic.ReplicationFactor,
+		RetentionMs:       topic.RetentionMs,
 		Config:            topic.Config,
 	}
 
@@ -319,11 +328,26 @@ func (r *GRPCTopicRepository) GetByName(ctx context.Context, name string) (*enti
 	}
 
 	// 转换为实体
+	partitions := convertPartitionInfos(resp.GetPartitions())
+	details := make([]*entities.PartitionInfo, len(partitions))
+	for i, partition := range partitions {
+		details[i] = &entities.PartitionInfo{
+			PartitionID:   partition.PartitionID,
+			LeaderID:      partition.LeaderID,
+			ReplicaIDs:    partition.ReplicaIDs,
+			ISRIDs:        partition.ISRIDs,
+			HighWatermark: partition.HighWatermark,
+			LowWatermark:  partition.LowWatermark,
+		}
+	}
+
 	topic := &entities.Topic{
 		Name:              resp.GetTopic(),
 		Partitions:        int32(len(resp.GetPartitions())),
-		ReplicationFactor: 1, // 默认值，protobuf中没有这个字段
+		ReplicationFactor: replicationFactorOf(partitions),
+		RetentionMs:       resp.GetRetentionMs(),
 		Config:            resp.GetConfig(),
+		PartitionDetails:  details,
 	}
 
 	r.logger.Debug("根据名称获取主题成功", logging.Field{Key: "topic", Value: name})
@@ -338,6 +362,69 @@ func (r *GRPCTopicRepository) GetDetail(ctx context.Context, name string) (*enti
 	return r.GetByName(ctx, name)
 }
 
+// GetTopicStats 获取主题统计（DTO接口）
+func (r *GRPCTopicRepository) GetTopicStats(ctx context.Context, req *dtos.TopicStatsRequest) (*dtos.TopicStatsResponse, error) {
+	r.logger.Debug("Getting topic stats",
+		logging.Field{Key: "topic", Value: req.Topic},
+		logging.Field{Key: "include_partitions", Value: req.IncludePartitions})
+
+	// 构建gRPC请求
+	grpcReq := &pb.GetTopicStatsRequest{
+		Topic:             req.Topic,
+		IncludePartitions: req.IncludePartitions,
+	}
+
+	// 调用gRPC服务
+	resp, err := r.client.GetTopicStats(ctx, grpcReq)
+	if err != nil {
+		r.logger.Error("获取主题统计失败", logging.Field{Key: "error", Value: err})
+		return nil, fmt.Errorf("failed to get topic stats: %w", err)
+	}
+
+	// 检查错误
+	if resp.GetError() != "" {
+		return &dtos.TopicStatsResponse{
+			Success: false,
+			Error:   resp.GetError(),
+		}, nil
+	}
+
+	// 转换响应
+	topics := make([]*dtos.TopicStatsDTO, len(resp.GetTopics()))
+	for i, topicStats := range resp.GetTopics() {
+		partitions := make([]*dtos.PartitionStatsDTO, len(topicStats.GetPartitions()))
+		for j, partition := range topicStats.GetPartitions() {
+			partitions[j] = &dtos.PartitionStatsDTO{
+				PartitionID:    partition.GetPartitionId(),
+				MessageCount:   partition.GetMessageCount(),
+				TotalSizeBytes: partition.GetTotalSizeBytes(),
+				HighWatermark:  partition.GetLatestOffset(),
+				LowWatermark:   partition.GetEarliestOffset(),
+				LastUpdated:    protoTime(partition.GetLastUpdated()),
+			}
+		}
+
+		topics[i] = &dtos.TopicStatsDTO{
+			Topic:             topicStats.GetTopic(),
+			TotalMessageCount: topicStats.GetTotalMessageCount(),
+			TotalSizeBytes:    topicStats.GetTotalSizeBytes(),
+			PartitionCount:    topicStats.GetPartitionCount(),
+			ReplicationFactor: topicStats.GetReplicationFactor(),
+			Partitions:        partitions,
+			CreatedAt:         protoTime(topicStats.GetCreatedAt()),
+			LastUpdated:       protoTime(topicStats.GetLastUpdated()),
+		}
+	}
+
+	r.logger.Debug("获取主题统计成功", logging.Field{Key: "count", Value: len(topics)})
+
+	return &dtos.TopicStatsResponse{
+		Topics:      topics,
+		CollectedAt: protoTime(resp.GetCollectedAt()),
+		Success:     true,
+	}, nil
+}
+
 // GetStats 获取主题统计
 func (r *GRPCTopicRepository) GetStats(ctx context.Context, name string) (*repositories.TopicStats, error) {
 	r.logger.Debug("Getting topic stats", logging.Field{Key: "name", Value: name})
@@ -457,3 +544,47 @@ func (r *GRPCTopicRepository) GetPartitionStats(ctx context.Context, name string
 	return nil, fmt.Errorf("partition %d not found for topic %s", partition, name)
 
 }
+
+// protoTime 将protobuf时间戳转换为time.Time，未设置时返回零值
+func protoTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// convertPartitionInfos 转换分区详情
+func convertPartitionInfos(infos []*pb.PartitionInfo) []*dtos.PartitionInfoDTO {
+	partitions := make([]*dtos.PartitionInfoDTO, len(infos))
+	for i, partition := range infos {
+		partitions[i] = &dtos.PartitionInfoDTO{
+			PartitionID:   partition.GetPartitionId(),
+			LeaderID:      int32(partition.GetLeaderId()),
+			ReplicaIDs:    toInt32IDs(partition.GetReplicaIds()),
+			ISRIDs:        toInt32IDs(partition.GetIsrIds()),
+			HighWatermark: partition.GetHighWatermark(),
+			LowWatermark:  partition.GetLogStartOffset(),
+		}
+	}
+	return partitions
+}
+
+// replicationFactorOf 以分区副本数的最大值作为主题的副本因子，服务端未返回副本信息时为1
+func replicationFactorOf(partitions []*dtos.PartitionInfoDTO) int32 {
+	factor := int32(1)
+	for _, partition := range partitions {
+		if n := int32(len(partition.ReplicaIDs)); n > factor {
+			factor = n
+		}
+	}
+	return factor
+}
+
+// toInt32IDs 将Broker ID列表转换为int32
+func toInt32IDs(ids []int64) []int32 {
+	result := make([]int32, len(ids))
+	for i, id := range ids {
+		result[i] = int32(id)
+	}
+	return result
+}
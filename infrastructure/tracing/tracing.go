@@ -0,0 +1,197 @@
+// Package tracing 链路追踪钩子
+//
+// Tracer 是追踪系统的适配点，默认使用 NoopTracer。跨进程传播使用W3C Trace Context
+// （traceparent、tracestate），生产时写入消息头，消费时从消息头中恢复。
+// 对接OpenTelemetry时实现 Tracer，在 Start 中用 SpanContextFromContext 取得上游上下文即可。
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// W3C Trace Context 头名称
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+// SpanKind span类型
+type SpanKind int
+
+// span类型常量
+const (
+	SpanKindInternal SpanKind = iota
+	SpanKindClient            // 一次RPC调用
+	SpanKindProducer          // 发送消息
+	SpanKindConsumer          // 接收或处理消息
+)
+
+// String 返回span类型字符串
+func (k SpanKind) String() string {
+	switch k {
+	case SpanKindClient:
+		return "client"
+	case SpanKindProducer:
+		return "producer"
+	case SpanKindConsumer:
+		return "consumer"
+	default:
+		return "internal"
+	}
+}
+
+// Attribute span属性
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// SpanContext 可跨进程传播的span标识
+type SpanContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	Flags      byte   // 0x01表示已采样
+	TraceState string // 原样传递的tracestate
+	Remote     bool   // 是否从消息头或请求中恢复
+}
+
+// IsValid trace id和span id均不为全零时有效
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// IsSampled 是否已采样
+func (sc SpanContext) IsSampled() bool {
+	return sc.Flags&0x01 != 0
+}
+
+// TraceParent 格式化为traceparent头的值
+func (sc SpanContext) TraceParent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), sc.Flags)
+}
+
+// ParseTraceParent 解析traceparent头
+func ParseTraceParent(value string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, fmt.Errorf("invalid traceparent %q", value)
+	}
+	// 版本00必须正好4段，更高版本允许追加字段
+	if parts[0] == "00" && len(parts) != 4 {
+		return sc, fmt.Errorf("invalid traceparent %q", value)
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, fmt.Errorf("invalid traceparent %q", value)
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, fmt.Errorf("invalid trace id in traceparent: %w", err)
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, fmt.Errorf("invalid span id in traceparent: %w", err)
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return sc, fmt.Errorf("invalid flags in traceparent: %w", err)
+	}
+	sc.Flags = flags[0]
+	if !sc.IsValid() {
+		return sc, fmt.Errorf("invalid traceparent %q: zero trace or span id", value)
+	}
+	sc.Remote = true
+	return sc, nil
+}
+
+// NewTraceID 生成随机trace id
+func NewTraceID() [16]byte {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// NewSpanID 生成随机span id
+func NewSpanID() [8]byte {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// Span 一次操作的追踪区间
+type Span interface {
+	SpanContext() SpanContext
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer 追踪器，实现需要支持并发调用
+// Start 返回的ctx应能通过SpanContextFromContext取得新span的SpanContext，以便继续传播
+type Tracer interface {
+	Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, Span)
+}
+
+// spanContextKey ctx中SpanContext的键
+type spanContextKey struct{}
+
+// ContextWithSpanContext 将SpanContext放入ctx
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext 获取ctx中的SpanContext
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// Inject 将ctx中的SpanContext写入headers，ctx中没有有效span时不修改headers
+func Inject(ctx context.Context, headers map[string]string) bool {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok {
+		return false
+	}
+	headers[TraceParentHeader] = sc.TraceParent()
+	if sc.TraceState != "" {
+		headers[TraceStateHeader] = sc.TraceState
+	} else {
+		delete(headers, TraceStateHeader)
+	}
+	return true
+}
+
+// Extract 从headers恢复SpanContext，headers中没有有效的traceparent时返回false
+func Extract(headers map[string]string) (SpanContext, bool) {
+	value, ok := headers[TraceParentHeader]
+	if !ok {
+		return SpanContext{}, false
+	}
+	sc, err := ParseTraceParent(value)
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.TraceState = headers[TraceStateHeader]
+	return sc, true
+}
+
+// NoopTracer 不记录任何span，但保留ctx中已有的SpanContext，使上游trace可以原样传递
+type NoopTracer struct{}
+
+// Start 实现Tracer接口
+func (NoopTracer) Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, Span) {
+	sc, _ := SpanContextFromContext(ctx)
+	return ctx, noopSpan{sc: sc}
+}
+
+// noopSpan 空span
+type noopSpan struct {
+	sc SpanContext
+}
+
+func (s noopSpan) SpanContext() SpanContext { return s.sc }
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantErr   bool
+		wantFlags byte
+	}{
+		{name: "sampled", value: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", wantFlags: 0x01},
+		{name: "not sampled", value: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00", wantFlags: 0x00},
+		{name: "surrounding whitespace", value: " 00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01\t", wantFlags: 0x01},
+		{name: "future version with extra field", value: "cc-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-09-extra", wantFlags: 0x09},
+		{name: "empty", value: "", wantErr: true},
+		{name: "too few fields", value: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331", wantErr: true},
+		{name: "version 00 with extra field", value: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra", wantErr: true},
+		{name: "forbidden version ff", value: "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", wantErr: true},
+		{name: "short trace id", value: "00-0af7651916cd43dd8448eb211c8031-b7ad6b7169203331-01", wantErr: true},
+		{name: "short span id", value: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b71692033-01", wantErr: true},
+		{name: "non-hex trace id", value: "00-0af7651916cd43dd8448eb211c80319z-b7ad6b7169203331-01", wantErr: true},
+		{name: "non-hex flags", value: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-0g", wantErr: true},
+		{name: "zero trace id", value: "00-00000000000000000000000000000000-b7ad6b7169203331-01", wantErr: true},
+		{name: "zero span id", value: "00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := ParseTraceParent(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTraceParent(%q) = %+v, want error", tt.value, sc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTraceParent(%q): %v", tt.value, err)
+			}
+			if !sc.IsValid() || !sc.Remote {
+				t.Errorf("span context = %+v, want valid remote", sc)
+			}
+			if sc.Flags != tt.wantFlags {
+				t.Errorf("flags = %#x, want %#x", sc.Flags, tt.wantFlags)
+			}
+			if got := sc.TraceParent()[3:52]; got != "0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331" {
+				t.Errorf("ids = %s", got)
+			}
+		})
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	sc := SpanContext{TraceID: NewTraceID(), SpanID: NewSpanID(), Flags: 0x01, TraceState: "vendor=abc"}
+	ctx := ContextWithSpanContext(context.Background(), sc)
+
+	headers := map[string]string{"content-type": "application/json", TraceStateHeader: "stale=1"}
+	if !Inject(ctx, headers) {
+		t.Fatal("Inject returned false for a valid span context")
+	}
+	got, ok := Extract(headers)
+	if !ok {
+		t.Fatalf("Extract(%v) failed", headers)
+	}
+	sc.Remote = true
+	if got != sc {
+		t.Errorf("Extract = %+v, want %+v", got, sc)
+	}
+
+	// 没有tracestate时删除旧值
+	sc.TraceState = ""
+	Inject(ContextWithSpanContext(context.Background(), sc), headers)
+	if _, ok := headers[TraceStateHeader]; ok {
+		t.Errorf("stale tracestate kept: %v", headers)
+	}
+
+	empty := map[string]string{}
+	if Inject(context.Background(), empty) || len(empty) != 0 {
+		t.Errorf("Inject without span modified headers: %v", empty)
+	}
+	if _, ok := Extract(map[string]string{TraceParentHeader: "garbage"}); ok {
+		t.Error("Extract accepted an invalid traceparent")
+	}
+}
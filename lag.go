@@ -0,0 +1,363 @@
+package fluvio
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/metrics"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// PartitionLag 消费者组在单个分区上的积压
+type PartitionLag struct {
+	Topic           string `json:"topic"`
+	Partition       int32  `json:"partition"`
+	CommittedOffset int64  `json:"committed_offset"` // -1 表示该分区尚未提交过偏移量
+	HighWatermark   int64  `json:"high_watermark"`
+	Lag             int64  `json:"lag"`
+}
+
+// ConsumerGroupLag 消费者组积压信息
+type ConsumerGroupLag struct {
+	GroupID     string          `json:"group_id"`
+	Partitions  []*PartitionLag `json:"partitions"`
+	TotalLag    int64           `json:"total_lag"`
+	CollectedAt time.Time       `json:"collected_at"`
+}
+
+// TopicLag 计算指定主题的积压总数
+func (l *ConsumerGroupLag) TopicLag(topic string) int64 {
+	var total int64
+	for _, p := range l.Partitions {
+		if p.Topic == topic {
+			total += p.Lag
+		}
+	}
+	return total
+}
+
+// ConsumerGroupLag 计算消费者组的积压
+// 已提交偏移量按"下一条待消费位置"处理，积压 = 高水位 - 已提交偏移量；
+// 未提交过偏移量的分区以日志起始偏移量计算
+func (a *AdminManager) ConsumerGroupLag(ctx context.Context, groupID string) (*ConsumerGroupLag, error) {
+	if !*a.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if groupID == "" {
+		return nil, errors.New(errors.ErrInvalidArgument, "group id cannot be empty")
+	}
+
+	a.logger.Debug("Computing consumer group lag", logging.Field{Key: "group_id", Value: groupID})
+
+	groupResp, err := a.appService.DescribeConsumerGroup(ctx, &dtos.DescribeConsumerGroupRequest{GroupID: groupID})
+	if err != nil {
+		a.logger.Error("Failed to describe consumer group", logging.Field{Key: "error", Value: err})
+		return nil, err
+	}
+	if groupResp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, groupResp.Error)
+	}
+
+	// 按主题归类已提交偏移量
+	committed := make(map[string]map[int32]int64)
+	for _, offset := range groupResp.Group.Offsets {
+		if committed[offset.Topic] == nil {
+			committed[offset.Topic] = make(map[int32]int64)
+		}
+		committed[offset.Topic][offset.Partition] = offset.CommittedOffset
+	}
+
+	topics := make([]string, 0, len(committed))
+	for topic := range committed {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	result := &ConsumerGroupLag{
+		GroupID:     groupID,
+		Partitions:  make([]*PartitionLag, 0),
+		CollectedAt: time.Now(),
+	}
+
+	for _, topic := range topics {
+		topicResp, err := a.appService.DescribeTopic(ctx, &dtos.DescribeTopicRequest{Name: topic})
+		if err != nil {
+			a.logger.Error("Failed to describe topic", logging.Field{Key: "topic", Value: topic}, logging.Field{Key: "error", Value: err})
+			return nil, err
+		}
+		if topicResp.Error != "" {
+			return nil, errors.New(errors.ErrOperation, topicResp.Error)
+		}
+
+		for _, partition := range topicResp.Topic.PartitionDetails {
+			offset, ok := committed[topic][partition.PartitionID]
+			if !ok {
+				offset = -1
+			}
+
+			base := offset
+			if base < 0 {
+				base = partition.LowWatermark
+			}
+			lag := partition.HighWatermark - base
+			if lag < 0 {
+				lag = 0
+			}
+
+			result.Partitions = append(result.Partitions, &PartitionLag{
+				Topic:           topic,
+				Partition:       partition.PartitionID,
+				CommittedOffset: offset,
+				HighWatermark:   partition.HighWatermark,
+				Lag:             lag,
+			})
+			result.TotalLag += lag
+
+			a.metrics.Set(metrics.ConsumerLag, metrics.Labels{
+				"group":     groupID,
+				"topic":     topic,
+				"partition": strconv.Itoa(int(partition.PartitionID)),
+			}, float64(lag))
+		}
+	}
+
+	a.logger.Debug("Consumer group lag computed",
+		logging.Field{Key: "group_id", Value: groupID},
+		logging.Field{Key: "total_lag", Value: result.TotalLag})
+
+	return result, nil
+}
+
+// LagEventType 积压事件类型
+type LagEventType string
+
+// 积压事件类型常量
+const (
+	LagEventThresholdExceeded  LagEventType = "threshold_exceeded"  // 积压升至阈值以上
+	LagEventThresholdRecovered LagEventType = "threshold_recovered" // 积压回落至阈值以下
+	LagEventCheckFailed        LagEventType = "check_failed"        // 积压计算失败
+)
+
+// LagEvent 积压事件
+type LagEvent struct {
+	Type      LagEventType      `json:"type"`
+	GroupID   string            `json:"group_id"`
+	Topic     string            `json:"topic,omitempty"` // 为空表示针对总积压
+	Partition int32             `json:"partition"`       // -1 表示针对总积压
+	Lag       int64             `json:"lag"`
+	Threshold int64             `json:"threshold"`
+	Snapshot  *ConsumerGroupLag `json:"-"`
+	Err       error             `json:"-"`
+	Time      time.Time         `json:"time"`
+}
+
+// LagMonitorOptions 积压监控选项
+type LagMonitorOptions struct {
+	Interval     time.Duration `json:"interval,omitempty"`      // 轮询间隔，默认30秒
+	Thresholds   []int64       `json:"thresholds"`              // 触发事件的积压阈值
+	PerPartition bool          `json:"per_partition,omitempty"` // 是否同时按分区检查阈值
+	BufferSize   int           `json:"buffer_size,omitempty"`   // 事件通道缓冲区大小，默认16
+}
+
+// LagMonitor 轮询消费者组积压并在跨越阈值时发出事件
+type LagMonitor struct {
+	admin   *AdminManager
+	groupID string
+	opts    LagMonitorOptions
+
+	mu      sync.RWMutex
+	last    *ConsumerGroupLag
+	levels  map[lagKey]int
+	cancel  context.CancelFunc
+	running bool
+}
+
+// lagKey 阈值状态的键
+type lagKey struct {
+	topic     string
+	partition int32
+}
+
+// NewLagMonitor 创建积压监控器
+func (a *AdminManager) NewLagMonitor(groupID string, opts *LagMonitorOptions) *LagMonitor {
+	o := LagMonitorOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 30 * time.Second
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = 16
+	}
+	o.Thresholds = append([]int64(nil), o.Thresholds...)
+	sort.Slice(o.Thresholds, func(i, j int) bool { return o.Thresholds[i] < o.Thresholds[j] })
+
+	return &LagMonitor{
+		admin:   a,
+		groupID: groupID,
+		opts:    o,
+		levels:  make(map[lagKey]int),
+	}
+}
+
+// Start 启动监控，返回事件通道；ctx取消或调用Stop后通道关闭
+func (m *LagMonitor) Start(ctx context.Context) (<-chan *LagEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.running {
+		return nil, errors.New(errors.ErrOperation, "lag monitor already running")
+	}
+	if len(m.opts.Thresholds) == 0 {
+		return nil, errors.New(errors.ErrInvalidArgument, "at least one threshold is required")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+	m.running = true
+
+	events := make(chan *LagEvent, m.opts.BufferSize)
+	go m.run(ctx, events)
+
+	m.admin.logger.Info("Lag monitor started",
+		logging.Field{Key: "group_id", Value: m.groupID},
+		logging.Field{Key: "interval", Value: m.opts.Interval})
+
+	return events, nil
+}
+
+// Stop 停止监控
+func (m *LagMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+// Last 获取最近一次的积压快照
+func (m *LagMonitor) Last() *ConsumerGroupLag {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last
+}
+
+// run 轮询循环
+func (m *LagMonitor) run(ctx context.Context, events chan<- *LagEvent) {
+	defer func() {
+		m.mu.Lock()
+		m.running = false
+		m.mu.Unlock()
+		close(events)
+		m.admin.logger.Info("Lag monitor stopped", logging.Field{Key: "group_id", Value: m.groupID})
+	}()
+
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if !m.poll(ctx, events) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll 执行一次检查，返回false表示应当退出
+func (m *LagMonitor) poll(ctx context.Context, events chan<- *LagEvent) bool {
+	snapshot, err := m.admin.ConsumerGroupLag(ctx, m.groupID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		m.admin.metrics.Add(metrics.LagCheckErrors, metrics.Labels{"group": m.groupID}, 1)
+		m.admin.logger.Warn("Lag check failed",
+			logging.Field{Key: "group_id", Value: m.groupID},
+			logging.Field{Key: "error", Value: err})
+		return m.emit(ctx, events, &LagEvent{
+			Type:      LagEventCheckFailed,
+			GroupID:   m.groupID,
+			Partition: -1,
+			Err:       err,
+			Time:      time.Now(),
+		})
+	}
+
+	m.mu.Lock()
+	m.last = snapshot
+	m.mu.Unlock()
+
+	pending := m.evaluate(lagKey{partition: -1}, snapshot.TotalLag, snapshot)
+	if m.opts.PerPartition {
+		for _, p := range snapshot.Partitions {
+			pending = append(pending, m.evaluate(lagKey{topic: p.Topic, partition: p.Partition}, p.Lag, snapshot)...)
+		}
+	}
+
+	for _, event := range pending {
+		if !m.emit(ctx, events, event) {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate 比较新旧阈值等级，生成跨越事件
+func (m *LagMonitor) evaluate(key lagKey, lag int64, snapshot *ConsumerGroupLag) []*LagEvent {
+	level := 0
+	for _, threshold := range m.opts.Thresholds {
+		if lag >= threshold {
+			level++
+		}
+	}
+
+	m.mu.Lock()
+	previous := m.levels[key]
+	m.levels[key] = level
+	m.mu.Unlock()
+
+	if level == previous {
+		return nil
+	}
+
+	event := &LagEvent{
+		GroupID:   m.groupID,
+		Topic:     key.topic,
+		Partition: key.partition,
+		Lag:       lag,
+		Snapshot:  snapshot,
+		Time:      snapshot.CollectedAt,
+	}
+	if level > previous {
+		event.Type = LagEventThresholdExceeded
+		event.Threshold = m.opts.Thresholds[level-1]
+	} else {
+		event.Type = LagEventThresholdRecovered
+		event.Threshold = m.opts.Thresholds[level]
+	}
+	return []*LagEvent{event}
+}
+
+// emit 发送事件，ctx取消时返回false
+func (m *LagMonitor) emit(ctx context.Context, events chan<- *LagEvent, event *LagEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
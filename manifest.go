@@ -0,0 +1,66 @@
+package fluvio
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestFiles 返回path对应的清单文件列表
+// path为目录时返回目录下所有 .yaml/.yml/.json 文件（按文件名排序，不递归）
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidArgument, "failed to stat manifest", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidArgument, "failed to read manifest directory", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isManifestFile(entry.Name()) {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// isManifestFile 检查文件扩展名是否为支持的清单格式
+func isManifestFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeManifestFile 按扩展名以JSON或YAML解析清单文件
+func decodeManifestFile(file string, v interface{}) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return errors.Wrap(errors.ErrInvalidArgument, "failed to read manifest", err)
+	}
+
+	if strings.ToLower(filepath.Ext(file)) == ".json" {
+		err = json.Unmarshal(data, v)
+	} else {
+		err = yaml.Unmarshal(data, v)
+	}
+	if err != nil {
+		return errors.Wrap(errors.ErrValidation, fmt.Sprintf("failed to parse manifest %s", file), err)
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package fluvio
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// DefaultMirrorOriginHeader 镜像写入目标主题时用于标记来源集群的消息头
+const DefaultMirrorOriginHeader = "x-fluvio-mirror-origin"
+
+// MirrorOptions 镜像选项
+type MirrorOptions struct {
+	SourceTopic   string        `json:"source_topic"`
+	TargetTopic   string        `json:"target_topic,omitempty"`   // 为空时与源主题同名
+	Group         string        `json:"group"`                    // 源集群上用于记录镜像进度的消费者组
+	Partitions    []int32       `json:"partitions,omitempty"`     // 为空表示源主题所有分区
+	From          *OffsetSpec   `json:"-"`                        // 消费者组未提交过偏移量时的起始位置，默认最早
+	BatchSize     int           `json:"batch_size,omitempty"`     // 每批最多消息数，默认100，最大MaxBatchSize
+	FlushInterval time.Duration `json:"flush_interval,omitempty"` // 未攒满一批时的最长等待时间，默认500ms
+
+	// 防回环：写入目标时在OriginHeader中记录SourceID（已有来源的消息保持原值），
+	// 来源等于TargetID的消息不再写回目标集群
+	OriginHeader string `json:"origin_header,omitempty"` // 默认DefaultMirrorOriginHeader
+	SourceID     string `json:"source_id,omitempty"`     // 默认源客户端地址
+	TargetID     string `json:"target_id,omitempty"`     // 默认目标客户端地址
+}
+
+// MirrorStats 镜像统计
+type MirrorStats struct {
+	Mirrored  int64           `json:"mirrored"`  // 已写入目标主题的消息数
+	Skipped   int64           `json:"skipped"`   // 因防回环跳过的消息数
+	Batches   int64           `json:"batches"`   // 已完成的批次数
+	Committed map[int32]int64 `json:"committed"` // 各分区最近一次提交的源偏移量
+}
+
+// Mirror 将源集群的主题持续复制到目标集群
+// 每个源分区独立消费，目标确认整批写入后才提交源偏移量，因此故障重启后可能重复但不会丢失消息；
+// 目标分区由目标服务端分配，同一源分区内的顺序在单次BatchProduce内保持
+type Mirror struct {
+	source *Client
+	target *Client
+	opts   MirrorOptions
+	logger logging.Logger
+
+	mirrored atomic.Int64
+	skipped  atomic.Int64
+	batches  atomic.Int64
+
+	mu        sync.Mutex
+	committed map[int32]int64
+}
+
+// NewMirror 创建镜像，source和target需已连接
+func NewMirror(source, target *Client, opts *MirrorOptions) (*Mirror, error) {
+	if source == nil || target == nil {
+		return nil, errors.New(errors.ErrInvalidArgument, "source and target clients are required")
+	}
+	if opts == nil || opts.SourceTopic == "" {
+		return nil, errors.New(errors.ErrInvalidArgument, "source topic cannot be empty")
+	}
+	if opts.Group == "" {
+		return nil, errors.New(errors.ErrInvalidArgument, "mirror group cannot be empty")
+	}
+
+	o := *opts
+	if o.TargetTopic == "" {
+		o.TargetTopic = o.SourceTopic
+	}
+	if o.From == nil {
+		o.From = OffsetBeginning()
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.BatchSize > MaxBatchSize {
+		o.BatchSize = MaxBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = 500 * time.Millisecond
+	}
+	if o.OriginHeader == "" {
+		o.OriginHeader = DefaultMirrorOriginHeader
+	}
+	if o.SourceID == "" {
+		o.SourceID = source.config.Connection.Address()
+	}
+	if o.TargetID == "" {
+		o.TargetID = target.config.Connection.Address()
+	}
+	if source == target && o.SourceTopic == o.TargetTopic {
+		return nil, errors.New(errors.ErrInvalidArgument, "source and target are the same topic")
+	}
+
+	return &Mirror{
+		source:    source,
+		target:    target,
+		opts:      o,
+		logger:    source.logger,
+		committed: make(map[int32]int64),
+	}, nil
+}
+
+// Run 启动镜像并阻塞，直到ctx取消（返回nil）或任一分区出错（返回该错误并停止其他分区）
+func (m *Mirror) Run(ctx context.Context) error {
+	partitions := m.opts.Partitions
+	if len(partitions) == 0 {
+		info, err := m.source.Topics().Info(ctx, m.opts.SourceTopic)
+		if err != nil {
+			return err
+		}
+		for p := int32(0); p < info.Partitions; p++ {
+			partitions = append(partitions, p)
+		}
+	}
+
+	starts, err := m.startOffsets(ctx, partitions)
+	if err != nil {
+		return err
+	}
+
+	m.logger.Info("Starting mirror",
+		logging.Field{Key: "source_topic", Value: m.opts.SourceTopic},
+		logging.Field{Key: "target_topic", Value: m.opts.TargetTopic},
+		logging.Field{Key: "source", Value: m.opts.SourceID},
+		logging.Field{Key: "target", Value: m.opts.TargetID},
+		logging.Field{Key: "partitions", Value: len(partitions)})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, len(partitions))
+	var wg sync.WaitGroup
+	for _, partition := range partitions {
+		wg.Add(1)
+		go func(partition int32, offset int64) {
+			defer wg.Done()
+			if err := m.runPartition(ctx, partition, offset); err != nil && ctx.Err() == nil {
+				errCh <- err
+				cancel()
+			}
+		}(partition, starts[partition])
+	}
+	wg.Wait()
+	close(errCh)
+
+	if err := <-errCh; err != nil {
+		m.logger.Error("Mirror stopped", logging.Field{Key: "error", Value: err})
+		return err
+	}
+	m.logger.Info("Mirror stopped",
+		logging.Field{Key: "mirrored", Value: m.mirrored.Load()},
+		logging.Field{Key: "skipped", Value: m.skipped.Load()})
+	return nil
+}
+
+// Stats 获取镜像统计
+func (m *Mirror) Stats() *MirrorStats {
+	m.mu.Lock()
+	committed := make(map[int32]int64, len(m.committed))
+	for p, offset := range m.committed {
+		committed[p] = offset
+	}
+	m.mu.Unlock()
+
+	return &MirrorStats{
+		Mirrored:  m.mirrored.Load(),
+		Skipped:   m.skipped.Load(),
+		Batches:   m.batches.Load(),
+		Committed: committed,
+	}
+}
+
+// startOffsets 确定各分区的起始偏移量：优先使用消费者组已提交的偏移量，否则解析From
+func (m *Mirror) startOffsets(ctx context.Context, partitions []int32) (map[int32]int64, error) {
+	committed, err := m.source.Admin().committedOffsets(ctx, m.opts.Group, m.opts.SourceTopic)
+	if err != nil {
+		// 消费者组尚不存在时视为没有提交记录
+		m.logger.Debug("No committed offsets for mirror group",
+			logging.Field{Key: "group", Value: m.opts.Group},
+			logging.Field{Key: "error", Value: err})
+		committed = nil
+	}
+
+	starts := make(map[int32]int64, len(partitions))
+	for _, partition := range partitions {
+		if offset, ok := committed[partition]; ok && offset >= 0 {
+			starts[partition] = offset
+			continue
+		}
+		offset, err := m.source.Consumer().ResolveOffset(ctx, m.opts.SourceTopic, partition, m.opts.From)
+		if err != nil {
+			return nil, err
+		}
+		starts[partition] = offset
+	}
+	return starts, nil
+}
+
+// runPartition 镜像单个分区
+func (m *Mirror) runPartition(ctx context.Context, partition int32, offset int64) error {
+	// 不把消费者组传给Stream，避免服务端在目标确认前推进进度；偏移量只由flush提交
+	p := partition
+	stream, err := m.source.Consumer().Stream(ctx, m.opts.SourceTopic, &StreamOptions{
+		Partition:  &p,
+		Offset:     offset,
+		BufferSize: m.opts.BatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(m.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Message, 0, m.opts.BatchSize)
+	next := offset // 当前批次全部确认后应提交的源偏移量
+	pending := false
+
+	flush := func() error {
+		if !pending {
+			return nil
+		}
+		if len(batch) > 0 {
+			result, err := m.target.Producer().SendBatch(ctx, m.opts.TargetTopic, batch)
+			if err != nil {
+				return err
+			}
+			if len(result.Failures) > 0 {
+				// 不提交，重启后从上次提交位置重新镜像
+				return errors.New(errors.ErrOperation, fmt.Sprintf("mirror %s/%d: target rejected %d of %d messages: %s",
+					m.opts.SourceTopic, partition, len(result.Failures), len(batch), result.Failures[0].Error))
+			}
+			m.mirrored.Add(int64(len(batch)))
+			m.batches.Add(1)
+		}
+
+		if err := m.source.Consumer().CommitPartition(ctx, m.opts.SourceTopic, m.opts.Group, partition, next); err != nil {
+			return err
+		}
+		m.mu.Lock()
+		m.committed[partition] = next
+		m.mu.Unlock()
+
+		batch = batch[:0]
+		pending = false
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := flush(); err != nil {
+				return err
+			}
+		case msg, ok := <-stream:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.New(errors.ErrUnavailable, fmt.Sprintf("mirror %s/%d: source stream closed", m.opts.SourceTopic, partition))
+			}
+			if msg.Offset < next {
+				continue
+			}
+			next = msg.Offset + 1
+			pending = true
+
+			if out := m.mirrorMessage(msg); out != nil {
+				batch = append(batch, out)
+			} else {
+				m.skipped.Add(1)
+			}
+			if len(batch) >= m.opts.BatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// mirrorMessage 构造写入目标的消息，来源为目标集群的消息返回nil
+func (m *Mirror) mirrorMessage(msg *ConsumedMessage) *Message {
+	origin := msg.Headers[m.opts.OriginHeader]
+	if origin == m.opts.TargetID {
+		return nil
+	}
+	if origin == "" {
+		origin = m.opts.SourceID
+	}
+
+	headers := make(map[string]string, len(msg.Headers)+1)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[m.opts.OriginHeader] = origin
+
+	return &Message{
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   headers,
+		Timestamp: msg.Timestamp,
+		MessageID: msg.MessageID,
+	}
+}
@@ -0,0 +1,209 @@
+package fluvio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// ResetOffsetsOptions 重置消费者组偏移量选项
+type ResetOffsetsOptions struct {
+	Topic      string      `json:"topic"`
+	Partitions []int32     `json:"partitions,omitempty"` // 为空表示主题的所有分区
+	To         *OffsetSpec `json:"-"`                    // 目标位置：最早、最新、时间点、绝对偏移量等
+	Shift      int64       `json:"shift,omitempty"`      // 在当前已提交偏移量基础上平移N条（To为nil时生效）
+	DryRun     bool        `json:"dry_run,omitempty"`    // 只计算计划，不提交
+}
+
+// OffsetResetPlan 单个分区的偏移量重置计划
+type OffsetResetPlan struct {
+	Topic         string `json:"topic"`
+	Partition     int32  `json:"partition"`
+	CurrentOffset int64  `json:"current_offset"` // -1 表示该分区尚未提交过偏移量
+	TargetOffset  int64  `json:"target_offset"`
+	Applied       bool   `json:"applied"`
+	Error         string `json:"error,omitempty"`
+}
+
+// ResetOffsetsResult 重置消费者组偏移量结果
+type ResetOffsetsResult struct {
+	GroupID string             `json:"group_id"`
+	DryRun  bool               `json:"dry_run"`
+	Plans   []*OffsetResetPlan `json:"plans"`
+}
+
+// ResetConsumerGroupOffsets 重置消费者组在指定主题分区上的偏移量
+// DryRun模式下只返回当前偏移量与目标偏移量，不做任何提交
+func (a *AdminManager) ResetConsumerGroupOffsets(ctx context.Context, groupID string, opts *ResetOffsetsOptions) (*ResetOffsetsResult, error) {
+	if !*a.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if groupID == "" {
+		return nil, errors.New(errors.ErrInvalidArgument, "group id cannot be empty")
+	}
+	if opts == nil || opts.Topic == "" {
+		return nil, errors.New(errors.ErrInvalidArgument, "topic is required")
+	}
+	if opts.To == nil && opts.Shift == 0 {
+		return nil, errors.New(errors.ErrInvalidArgument, "either To or Shift must be set")
+	}
+	if opts.To != nil && opts.Shift != 0 {
+		return nil, errors.New(errors.ErrInvalidArgument, "To and Shift are mutually exclusive")
+	}
+
+	a.logger.Debug("Resetting consumer group offsets",
+		logging.Field{Key: "group_id", Value: groupID},
+		logging.Field{Key: "topic", Value: opts.Topic},
+		logging.Field{Key: "dry_run", Value: opts.DryRun})
+
+	partitions, err := a.resetPartitions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := a.committedOffsets(ctx, groupID, opts.Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &offsetResolver{
+		appService: a.appService,
+		logger:     a.logger,
+	}
+
+	// 先计算所有分区的目标偏移量，任何分区解析失败都不做提交
+	plans := make([]*OffsetResetPlan, 0, len(partitions))
+	for _, partition := range partitions {
+		currentOffset, ok := current[partition]
+		if !ok {
+			currentOffset = -1
+		}
+
+		var target int64
+		if opts.To != nil {
+			target, err = resolver.resolve(ctx, opts.Topic, partition, opts.To)
+		} else {
+			target, err = a.shiftOffset(ctx, opts.Topic, partition, currentOffset, opts.Shift)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		plans = append(plans, &OffsetResetPlan{
+			Topic:         opts.Topic,
+			Partition:     partition,
+			CurrentOffset: currentOffset,
+			TargetOffset:  target,
+		})
+	}
+
+	result := &ResetOffsetsResult{
+		GroupID: groupID,
+		DryRun:  opts.DryRun,
+		Plans:   plans,
+	}
+
+	if opts.DryRun {
+		a.logger.Info("Consumer group offset reset planned (dry run)",
+			logging.Field{Key: "group_id", Value: groupID},
+			logging.Field{Key: "partitions", Value: len(plans)})
+		return result, nil
+	}
+
+	failed := 0
+	for _, plan := range plans {
+		if err := a.appService.CommitOffset(ctx, plan.Topic, plan.Partition, groupID, plan.TargetOffset); err != nil {
+			plan.Error = err.Error()
+			failed++
+			continue
+		}
+		plan.Applied = true
+	}
+
+	if failed > 0 {
+		a.logger.Error("Consumer group offset reset partially failed",
+			logging.Field{Key: "group_id", Value: groupID},
+			logging.Field{Key: "failed", Value: failed},
+			logging.Field{Key: "total", Value: len(plans)})
+		return result, errors.New(errors.ErrOperation,
+			fmt.Sprintf("failed to reset %d of %d partitions", failed, len(plans)))
+	}
+
+	a.logger.Info("Consumer group offsets reset successfully",
+		logging.Field{Key: "group_id", Value: groupID},
+		logging.Field{Key: "topic", Value: opts.Topic},
+		logging.Field{Key: "partitions", Value: len(plans)})
+
+	return result, nil
+}
+
+// resetPartitions 确定需要重置的分区列表
+func (a *AdminManager) resetPartitions(ctx context.Context, opts *ResetOffsetsOptions) ([]int32, error) {
+	if len(opts.Partitions) > 0 {
+		return opts.Partitions, nil
+	}
+
+	resp, err := a.appService.DescribeTopic(ctx, &dtos.DescribeTopicRequest{Name: opts.Topic})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	// 使用主题实际报告的分区编号，与ConsumerGroupLag一致
+	partitions := make([]int32, 0, len(resp.Topic.PartitionDetails))
+	for _, partition := range resp.Topic.PartitionDetails {
+		partitions = append(partitions, partition.PartitionID)
+	}
+	if len(partitions) == 0 {
+		return nil, errors.New(errors.ErrNotFound, fmt.Sprintf("topic %s reports no partitions", opts.Topic))
+	}
+	return partitions, nil
+}
+
+// committedOffsets 获取消费者组在指定主题上各分区的已提交偏移量
+func (a *AdminManager) committedOffsets(ctx context.Context, groupID, topic string) (map[int32]int64, error) {
+	resp, err := a.appService.DescribeConsumerGroup(ctx, &dtos.DescribeConsumerGroupRequest{GroupID: groupID})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	offsets := make(map[int32]int64)
+	for _, offset := range resp.Group.Offsets {
+		if offset.Topic == topic {
+			offsets[offset.Partition] = offset.CommittedOffset
+		}
+	}
+	return offsets, nil
+}
+
+// shiftOffset 在当前偏移量基础上平移，结果限制在分区的有效范围内
+// 未提交过偏移量的分区以最早偏移量为基准
+func (a *AdminManager) shiftOffset(ctx context.Context, topic string, partition int32, current, shift int64) (int64, error) {
+	stats, err := a.appService.GetPartitionStats(ctx, topic, partition)
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrOperation, "failed to get partition stats", err)
+	}
+
+	base := current
+	if base < 0 {
+		base = stats.LowWatermark
+	}
+
+	target := base + shift
+	if target < stats.LowWatermark {
+		target = stats.LowWatermark
+	}
+	if target > stats.HighWatermark {
+		target = stats.HighWatermark
+	}
+	return target, nil
+}
@@ -0,0 +1,221 @@
+package fluvio
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
+	"github.com/iwen-conf/fluvio_grpc_client/application/services"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// OffsetSpecKind 偏移量规格类型
+type OffsetSpecKind int
+
+// 偏移量规格类型常量
+const (
+	OffsetKindAbsolute  OffsetSpecKind = iota // 绝对偏移量
+	OffsetKindBeginning                       // 分区最早的偏移量
+	OffsetKindEnd                             // 分区最新的偏移量（只消费新消息）
+	OffsetKindFromEnd                         // 距离末尾N条
+	OffsetKindTimestamp                       // 第一条时间戳不早于指定时间的消息
+)
+
+// String 返回类型名称
+func (k OffsetSpecKind) String() string {
+	switch k {
+	case OffsetKindAbsolute:
+		return "absolute"
+	case OffsetKindBeginning:
+		return "beginning"
+	case OffsetKindEnd:
+		return "end"
+	case OffsetKindFromEnd:
+		return "from_end"
+	case OffsetKindTimestamp:
+		return "timestamp"
+	default:
+		return "unknown"
+	}
+}
+
+// OffsetSpec 描述消费的起始位置
+// 除绝对偏移量外，其余规格都需要在消费前通过GetTopicStats解析为具体偏移量
+type OffsetSpec struct {
+	kind      OffsetSpecKind
+	value     int64
+	timestamp time.Time
+}
+
+// OffsetBeginning 从分区最早的消息开始
+func OffsetBeginning() *OffsetSpec {
+	return &OffsetSpec{kind: OffsetKindBeginning}
+}
+
+// OffsetEnd 从分区末尾开始，只消费之后写入的消息
+func OffsetEnd() *OffsetSpec {
+	return &OffsetSpec{kind: OffsetKindEnd}
+}
+
+// OffsetFromEnd 从距离分区末尾n条消息的位置开始
+func OffsetFromEnd(n int64) *OffsetSpec {
+	return &OffsetSpec{kind: OffsetKindFromEnd, value: n}
+}
+
+// OffsetAbsolute 从指定的绝对偏移量开始
+func OffsetAbsolute(offset int64) *OffsetSpec {
+	return &OffsetSpec{kind: OffsetKindAbsolute, value: offset}
+}
+
+// OffsetAtTime 从第一条时间戳不早于t的消息开始
+func OffsetAtTime(t time.Time) *OffsetSpec {
+	return &OffsetSpec{kind: OffsetKindTimestamp, timestamp: t}
+}
+
+// Kind 获取规格类型
+func (s *OffsetSpec) Kind() OffsetSpecKind {
+	return s.kind
+}
+
+// Value 获取偏移量或条数（仅对Absolute和FromEnd有意义）
+func (s *OffsetSpec) Value() int64 {
+	return s.value
+}
+
+// Time 获取时间点（仅对AtTime有意义）
+func (s *OffsetSpec) Time() time.Time {
+	return s.timestamp
+}
+
+// String 返回规格的字符串表示
+func (s *OffsetSpec) String() string {
+	switch s.kind {
+	case OffsetKindAbsolute, OffsetKindFromEnd:
+		return fmt.Sprintf("%s(%d)", s.kind, s.value)
+	case OffsetKindTimestamp:
+		return fmt.Sprintf("%s(%s)", s.kind, s.timestamp.Format(time.RFC3339))
+	default:
+		return s.kind.String()
+	}
+}
+
+// validate 验证规格参数
+func (s *OffsetSpec) validate() error {
+	switch s.kind {
+	case OffsetKindAbsolute:
+		if s.value < 0 {
+			return errors.New(errors.ErrInvalidArgument, "absolute offset cannot be negative")
+		}
+	case OffsetKindFromEnd:
+		if s.value < 0 {
+			return errors.New(errors.ErrInvalidArgument, "from-end count cannot be negative")
+		}
+	case OffsetKindTimestamp:
+		if s.timestamp.IsZero() {
+			return errors.New(errors.ErrInvalidArgument, "timestamp cannot be zero")
+		}
+	case OffsetKindBeginning, OffsetKindEnd:
+	default:
+		return errors.New(errors.ErrInvalidArgument, fmt.Sprintf("unknown offset spec kind: %d", s.kind))
+	}
+	return nil
+}
+
+// offsetResolver 将OffsetSpec解析为具体偏移量
+type offsetResolver struct {
+	appService *services.FluvioApplicationService
+	logger     logging.Logger
+}
+
+// resolve 解析指定分区上的偏移量规格
+func (r *offsetResolver) resolve(ctx context.Context, topic string, partition int32, spec *OffsetSpec) (int64, error) {
+	if spec == nil {
+		return 0, errors.New(errors.ErrInvalidArgument, "offset spec cannot be nil")
+	}
+	if err := spec.validate(); err != nil {
+		return 0, err
+	}
+
+	// 绝对偏移量无需查询服务器
+	if spec.kind == OffsetKindAbsolute {
+		return spec.value, nil
+	}
+
+	stats, err := r.appService.GetPartitionStats(ctx, topic, partition)
+	if err != nil {
+		r.logger.Error("Failed to get partition stats for offset resolution",
+			logging.Field{Key: "topic", Value: topic},
+			logging.Field{Key: "partition", Value: partition},
+			logging.Field{Key: "error", Value: err})
+		return 0, errors.Wrap(errors.ErrOperation, "failed to resolve offset", err)
+	}
+
+	earliest, latest := stats.LowWatermark, stats.HighWatermark
+
+	var offset int64
+	switch spec.kind {
+	case OffsetKindBeginning:
+		offset = earliest
+	case OffsetKindEnd:
+		offset = latest
+	case OffsetKindFromEnd:
+		offset = latest - spec.value
+		if offset < earliest {
+			offset = earliest
+		}
+	case OffsetKindTimestamp:
+		offset, err = r.searchTimestamp(ctx, topic, partition, earliest, latest, spec.timestamp)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	r.logger.Debug("Offset resolved",
+		logging.Field{Key: "topic", Value: topic},
+		logging.Field{Key: "partition", Value: partition},
+		logging.Field{Key: "spec", Value: spec.String()},
+		logging.Field{Key: "offset", Value: offset})
+
+	return offset, nil
+}
+
+// searchTimestamp 在[earliest, latest)范围内二分查找第一条时间戳不早于t的消息
+// 找不到时返回latest
+func (r *offsetResolver) searchTimestamp(ctx context.Context, topic string, partition int32, earliest, latest int64, t time.Time) (int64, error) {
+	lo, hi := earliest, latest
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		resp, err := r.appService.ConsumeMessage(ctx, &dtos.ConsumeMessageRequest{
+			Topic:       topic,
+			Partition:   partition,
+			Offset:      mid,
+			MaxMessages: 1,
+		})
+		if err != nil {
+			return 0, errors.Wrap(errors.ErrOperation, "failed to search offset by timestamp", err)
+		}
+
+		// mid之后没有消息，目标位置只可能在mid之前
+		if len(resp.Messages) == 0 {
+			hi = mid
+			continue
+		}
+
+		msg := resp.Messages[0]
+		if msg.Timestamp.Before(t) {
+			// 服务端可能跳过已压缩的偏移量，以实际返回的偏移量为准
+			next := msg.Offset + 1
+			if next <= mid {
+				next = mid + 1
+			}
+			lo = next
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo, nil
+}
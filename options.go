@@ -1,7 +1,10 @@
 package fluvio
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/config"
@@ -19,6 +22,58 @@ func WithAddress(host string, port int) ClientOption {
 	}
 }
 
+// WithEndpoints 设置多个服务器地址（host:port），取代WithAddress；域名会解析为全部地址
+func WithEndpoints(endpoints ...string) ClientOption {
+	return func(cfg *config.Config) error {
+		if len(endpoints) == 0 {
+			return fmt.Errorf("at least one endpoint is required")
+		}
+		for _, endpoint := range endpoints {
+			if host, port, err := net.SplitHostPort(endpoint); err != nil || host == "" || port == "" {
+				return fmt.Errorf("invalid endpoint %q: expected host:port", endpoint)
+			}
+		}
+		cfg.Connection.WithEndpoints(endpoints...)
+		return nil
+	}
+}
+
+// WithLoadBalancing 设置多地址时的负载均衡策略
+func WithLoadBalancing(policy LoadBalancingPolicy) ClientOption {
+	return func(cfg *config.Config) error {
+		switch policy {
+		case LoadBalancingPickFirst, LoadBalancingRoundRobin:
+		default:
+			return fmt.Errorf("unsupported load balancing policy %q", policy)
+		}
+		cfg.Connection.LoadBalancing = string(policy)
+		return nil
+	}
+}
+
+// WithEndpointHealthCheck 按interval对每个地址调用HealthCheck，剔除不健康的地址，恢复后重新加入
+// 当前地址被剔除后，请求和重连会转到健康的地址
+func WithEndpointHealthCheck(interval time.Duration) ClientOption {
+	return func(cfg *config.Config) error {
+		if interval <= 0 {
+			return fmt.Errorf("health check interval must be positive")
+		}
+		cfg.Connection.HealthCheckInterval = interval
+		return nil
+	}
+}
+
+// WithHealthProbe 开启后台健康探测，按间隔调用HealthCheck，失败时状态变为StateUnhealthy；0表示关闭（默认）
+func WithHealthProbe(interval time.Duration) ClientOption {
+	return func(cfg *config.Config) error {
+		if interval < 0 {
+			return fmt.Errorf("health probe interval cannot be negative")
+		}
+		cfg.Client.HealthProbeInterval = interval
+		return nil
+	}
+}
+
 // WithTimeout 设置超时时间
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(cfg *config.Config) error {
@@ -44,13 +99,74 @@ func WithRetry(maxRetries int, backoff time.Duration) ClientOption {
 }
 
 // WithTLS 设置TLS配置
+// certFile和keyFile为空时只校验服务端证书；caFile为空时使用系统根证书
 func WithTLS(certFile, keyFile, caFile string) ClientOption {
 	return func(cfg *config.Config) error {
+		if (certFile == "") != (keyFile == "") {
+			return fmt.Errorf("certFile and keyFile must be set together")
+		}
 		cfg.Connection.WithTLS(certFile, keyFile, caFile)
 		return nil
 	}
 }
 
+// WithServerTLS 启用只校验服务端证书的TLS，caFile为空时使用系统根证书
+func WithServerTLS(caFile string) ClientOption {
+	return WithTLS("", "", caFile)
+}
+
+// WithTLSPEM 使用内存中的PEM数据配置TLS，certPEM和keyPEM可以同时为空，caPEM为空时使用系统根证书
+func WithTLSPEM(certPEM, keyPEM, caPEM []byte) ClientOption {
+	return func(cfg *config.Config) error {
+		if (len(certPEM) == 0) != (len(keyPEM) == 0) {
+			return fmt.Errorf("certPEM and keyPEM must be set together")
+		}
+		cfg.Connection.TLSEnabled = true
+		cfg.Connection.CertPEM = certPEM
+		cfg.Connection.KeyPEM = keyPEM
+		cfg.Connection.CAPEM = caPEM
+		return nil
+	}
+}
+
+// WithTLSConfig 使用自定义TLS配置并启用TLS
+// 其他TLS选项（证书、CA、服务器名、WithInsecure）会在它的副本上继续生效
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(cfg *config.Config) error {
+		if tlsConfig == nil {
+			return fmt.Errorf("tls config cannot be nil")
+		}
+		cfg.Connection.TLSEnabled = true
+		cfg.Connection.TLSConfig = tlsConfig
+		return nil
+	}
+}
+
+// WithTLSServerName 覆盖用于校验服务端证书和SNI的服务器名并启用TLS
+// 适用于通过IP或负载均衡地址连接、而证书签发给其他域名的情况
+func WithTLSServerName(serverName string) ClientOption {
+	return func(cfg *config.Config) error {
+		if serverName == "" {
+			return fmt.Errorf("server name cannot be empty")
+		}
+		cfg.Connection.TLSEnabled = true
+		cfg.Connection.ServerName = serverName
+		return nil
+	}
+}
+
+// WithTLSReload 按interval检查证书和CA文件的修改时间，文件轮换后在下次握手时使用新证书
+// 只对文件形式的证书生效，不需要重启客户端
+func WithTLSReload(interval time.Duration) ClientOption {
+	return func(cfg *config.Config) error {
+		if interval <= 0 {
+			return fmt.Errorf("reload interval must be positive")
+		}
+		cfg.Connection.CertReloadInterval = interval
+		return nil
+	}
+}
+
 // WithLogger 设置自定义日志器
 func WithLogger(logger logging.Logger) ClientOption {
 	return func(cfg *config.Config) error {
@@ -72,6 +188,99 @@ func WithLogger(logger logging.Logger) ClientOption {
 	}
 }
 
+// WithMetrics 启用客户端指标
+// sink为nil时使用内置的MetricsRegistry，可通过Client.MetricsHandler()以Prometheus文本格式输出
+func WithMetrics(sink MetricsSink) ClientOption {
+	return func(cfg *config.Config) error {
+		cfg.Client.Metrics = true
+		if sink == nil {
+			return nil
+		}
+		if cfg.Extensions == nil {
+			cfg.Extensions = make(map[string]interface{})
+		}
+		cfg.Extensions["metrics_sink"] = sink
+		return nil
+	}
+}
+
+// WithTracing 启用链路追踪
+// 每次RPC生成client span，发送消息时把trace上下文写入消息头（traceparent/tracestate）；
+// tracer为nil时使用NoopTracer，只传递ctx中已有的trace上下文
+func WithTracing(tracer Tracer) ClientOption {
+	return func(cfg *config.Config) error {
+		cfg.Client.Tracing = true
+		if tracer == nil {
+			return nil
+		}
+		if cfg.Extensions == nil {
+			cfg.Extensions = make(map[string]interface{})
+		}
+		cfg.Extensions["tracer"] = tracer
+		return nil
+	}
+}
+
+// WithUserAgent 设置gRPC请求的user-agent
+func WithUserAgent(userAgent string) ClientOption {
+	return func(cfg *config.Config) error {
+		cfg.Client.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithRequestID 设置是否为没有请求ID的调用自动生成请求ID
+// 通过ContextWithRequestID传入的请求ID总是会发送
+func WithRequestID(enabled bool) ClientOption {
+	return func(cfg *config.Config) error {
+		cfg.Client.RequestID = enabled
+		return nil
+	}
+}
+
+// WithHeaders 设置每次RPC附加的元数据，可多次调用累加
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(cfg *config.Config) error {
+		if cfg.Client.Headers == nil {
+			cfg.Client.Headers = make(map[string]string, len(headers))
+		}
+		for k, v := range headers {
+			cfg.Client.Headers[strings.ToLower(k)] = v
+		}
+		return nil
+	}
+}
+
+// WithTokenSource 使用令牌认证，令牌以 authorization: Bearer <token> 发送
+// 令牌在过期前自动刷新；一元调用返回UNAUTHENTICATED时强制刷新并重试一次，
+// 流返回UNAUTHENTICATED时不重试，但会丢弃缓存的令牌，重新建立的流使用新令牌
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(cfg *config.Config) error {
+		if source == nil {
+			return fmt.Errorf("token source cannot be nil")
+		}
+		if cfg.Extensions == nil {
+			cfg.Extensions = make(map[string]interface{})
+		}
+		cfg.Extensions["token_source"] = source
+		return nil
+	}
+}
+
+// WithAPIKey 使用API key认证，以 x-api-key 元数据发送
+func WithAPIKey(key string) ClientOption {
+	return func(cfg *config.Config) error {
+		if key == "" {
+			return fmt.Errorf("api key cannot be empty")
+		}
+		if cfg.Extensions == nil {
+			cfg.Extensions = make(map[string]interface{})
+		}
+		cfg.Extensions["api_key"] = key
+		return nil
+	}
+}
+
 // WithLogLevel 设置日志级别
 func WithLogLevel(level LogLevel) ClientOption {
 	return func(cfg *config.Config) error {
@@ -88,7 +297,7 @@ func WithConnectionPool(size int, maxIdle time.Duration) ClientOption {
 	}
 }
 
-// WithInsecure 设置不安全连接（跳过TLS验证）
+// WithInsecure 设置不安全连接（跳过TLS验证），仅在启用TLS时生效
 func WithInsecure() ClientOption {
 	return func(cfg *config.Config) error {
 		cfg.Connection.Insecure = true
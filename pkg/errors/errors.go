@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 )
 
@@ -86,22 +87,35 @@ func Wrap(code ErrorCode, message string, cause error) *FluvioError {
 	}
 }
 
-// IsCode 检查错误代码
+// IsCode 检查错误链中是否有指定代码的FluvioError
 func IsCode(err error, code ErrorCode) bool {
-	if fluvioErr, ok := err.(*FluvioError); ok {
-		return fluvioErr.Code == code
+	for err != nil {
+		var fluvioErr *FluvioError
+		if !stderrors.As(err, &fluvioErr) {
+			return false
+		}
+		if fluvioErr.Code == code {
+			return true
+		}
+		err = fluvioErr.Cause
 	}
 	return false
 }
 
-// GetCode 获取错误代码
+// GetCode 获取错误代码，会沿错误链查找FluvioError
 func GetCode(err error) ErrorCode {
-	if fluvioErr, ok := err.(*FluvioError); ok {
+	var fluvioErr *FluvioError
+	if stderrors.As(err, &fluvioErr) {
 		return fluvioErr.Code
 	}
 	return ErrInternal
 }
 
+// IsAuthenticationError 检查是否为认证错误
+func IsAuthenticationError(err error) bool {
+	return IsCode(err, ErrAuthentication)
+}
+
 // IsRetryable 检查错误是否可重试
 func IsRetryable(err error) bool {
 	code := GetCode(err)
@@ -134,3 +148,12 @@ func IsPermanent(err error) bool {
 		return false
 	}
 }
+
+// RequestID 获取错误链中携带的请求ID，没有时返回空串
+func RequestID(err error) string {
+	var carrier interface{ GetRequestID() string }
+	if stderrors.As(err, &carrier) {
+		return carrier.GetRequestID()
+	}
+	return ""
+}
@@ -227,13 +227,28 @@ func (c *DTOConverter) SmartModuleDTOToProtoSpec(dto *dtos.SmartModuleDTO) *pb.S
 		return nil
 	}
 
-	return &pb.SmartModuleSpec{
+	spec := &pb.SmartModuleSpec{
 		Name:        dto.Name,
 		Version:     dto.Version,
 		Description: dto.Description,
 		InputKind:   pb.SmartModuleInput_SMART_MODULE_INPUT_STREAM,   // 默认值
 		OutputKind:  pb.SmartModuleOutput_SMART_MODULE_OUTPUT_STREAM, // 默认值
 	}
+	if dto.InputKind == "table" {
+		spec.InputKind = pb.SmartModuleInput_SMART_MODULE_INPUT_TABLE
+	}
+	if dto.OutputKind == "table" {
+		spec.OutputKind = pb.SmartModuleOutput_SMART_MODULE_OUTPUT_TABLE
+	}
+	for _, param := range dto.Parameters {
+		spec.Parameters = append(spec.Parameters, &pb.SmartModuleParameter{
+			Name:        param.Name,
+			Description: param.Description,
+			Optional:    param.Optional,
+		})
+	}
+
+	return spec
 }
 
 // ProtoSpecToSmartModuleDTO 将protobuf规格转换为SmartModule DTO
@@ -242,10 +257,33 @@ func (c *DTOConverter) ProtoSpecToSmartModuleDTO(spec *pb.SmartModuleSpec) *dtos
 		return nil
 	}
 
-	return &dtos.SmartModuleDTO{
+	dto := &dtos.SmartModuleDTO{
 		Name:        spec.GetName(),
 		Version:     spec.GetVersion(),
 		Description: spec.GetDescription(),
+		InputKind:   smartModuleKindName(int32(spec.GetInputKind())),
+		OutputKind:  smartModuleKindName(int32(spec.GetOutputKind())),
+	}
+	for _, param := range spec.GetParameters() {
+		dto.Parameters = append(dto.Parameters, &dtos.SmartModuleParameterDTO{
+			Name:        param.GetName(),
+			Description: param.GetDescription(),
+			Optional:    param.GetOptional(),
+		})
+	}
+
+	return dto
+}
+
+// smartModuleKindName 将输入/输出类型枚举值转换为名称，输入与输出枚举的取值一致
+func smartModuleKindName(kind int32) string {
+	switch kind {
+	case int32(pb.SmartModuleInput_SMART_MODULE_INPUT_STREAM):
+		return "stream"
+	case int32(pb.SmartModuleInput_SMART_MODULE_INPUT_TABLE):
+		return "table"
+	default:
+		return ""
 	}
 }
 
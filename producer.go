@@ -8,6 +8,7 @@ import (
 	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
 	"github.com/iwen-conf/fluvio_grpc_client/application/services"
 	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/tracing"
 	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
 )
 
@@ -15,6 +16,7 @@ import (
 type Producer struct {
 	appService *services.FluvioApplicationService
 	logger     logging.Logger
+	tracer     tracing.Tracer
 	connected  *bool
 }
 
@@ -23,7 +25,8 @@ type Message struct {
 	Key       string            `json:"key,omitempty"`
 	Value     []byte            `json:"value"`
 	Headers   map[string]string `json:"headers,omitempty"`
-	Timestamp time.Time         `json:"timestamp,omitempty"`
+	Timestamp time.Time         `json:"timestamp,omitempty"`  // 为零值时由服务端使用当前时间
+	MessageID string            `json:"message_id,omitempty"` // 为空时由服务端生成
 }
 
 // SendOptions 发送选项
@@ -43,12 +46,23 @@ type SendResult struct {
 }
 
 // BatchSendResult 批量发送结果
+// Results只包含发送成功的消息，失败的消息记录在Failures中
 type BatchSendResult struct {
-	Results      []*SendResult `json:"results"`
-	SuccessCount int           `json:"success_count"`
-	FailureCount int           `json:"failure_count"`
+	Results      []*SendResult       `json:"results"`
+	Failures     []*BatchSendFailure `json:"failures,omitempty"`
+	SuccessCount int                 `json:"success_count"`
+	FailureCount int                 `json:"failure_count"`
 }
 
+// BatchSendFailure 批量发送中失败的消息
+type BatchSendFailure struct {
+	Index int    `json:"index"` // 消息在输入列表中的下标
+	Error string `json:"error"`
+}
+
+// MaxBatchSize 单次BatchProduce请求的最大消息数，SendBatch会按此大小自动分批
+const MaxBatchSize = 1000
+
 // Send 发送单条消息
 func (p *Producer) Send(ctx context.Context, topic string, message *Message) (*SendResult, error) {
 	if !*p.connected {
@@ -59,15 +73,21 @@ func (p *Producer) Send(ctx context.Context, topic string, message *Message) (*S
 		logging.Field{Key: "topic", Value: topic},
 		logging.Field{Key: "key", Value: message.Key})
 
+	ctx, span := p.startSpan(ctx, topic, 1)
+	defer span.End()
+
 	req := &dtos.ProduceMessageRequest{
-		Topic:   topic,
-		Key:     message.Key,
-		Value:   string(message.Value),
-		Headers: message.Headers,
+		Topic:     topic,
+		Key:       message.Key,
+		Value:     string(message.Value),
+		Headers:   traceHeaders(ctx, message.Headers),
+		MessageID: message.MessageID,
+		Timestamp: message.Timestamp,
 	}
 
 	resp, err := p.appService.ProduceMessage(ctx, req)
 	if err != nil {
+		span.RecordError(err)
 		p.logger.Error("Failed to send message", logging.Field{Key: "error", Value: err})
 		return nil, err
 	}
@@ -92,15 +112,18 @@ func (p *Producer) SendWithOptions(ctx context.Context, opts *SendOptions) (*Sen
 	}
 
 	message := &Message{
-		Key:     opts.Key,
-		Value:   opts.Value,
-		Headers: opts.Headers,
+		Key:       opts.Key,
+		Value:     opts.Value,
+		Headers:   opts.Headers,
+		MessageID: opts.MessageID,
 	}
 
 	return p.Send(ctx, opts.Topic, message)
 }
 
-// SendBatch 批量发送消息
+// SendBatch 通过BatchProduce批量发送消息，超过MaxBatchSize时自动分批
+// 单条消息失败不会中断发送，失败信息记录在结果的Failures中；
+// 某一批请求本身失败时停止发送，该批及之后未发送的消息也记入Failures，并同时返回已有结果和错误
 func (p *Producer) SendBatch(ctx context.Context, topic string, messages []*Message) (*BatchSendResult, error) {
 	if !*p.connected {
 		return nil, errors.New(errors.ErrConnection, "client not connected")
@@ -110,37 +133,91 @@ func (p *Producer) SendBatch(ctx context.Context, topic string, messages []*Mess
 		logging.Field{Key: "topic", Value: topic},
 		logging.Field{Key: "count", Value: len(messages)})
 
-	var results []*SendResult
-	successCount := 0
-	failureCount := 0
+	ctx, span := p.startSpan(ctx, topic, len(messages))
+	defer span.End()
+
+	batchResult := &BatchSendResult{}
+
+	for start := 0; start < len(messages); start += MaxBatchSize {
+		end := start + MaxBatchSize
+		if end > len(messages) {
+			end = len(messages)
+		}
+
+		req := &dtos.ProduceBatchRequest{Messages: make([]*dtos.ProduceMessageRequest, 0, end-start)}
+		for _, message := range messages[start:end] {
+			req.Messages = append(req.Messages, &dtos.ProduceMessageRequest{
+				Topic:     topic,
+				Key:       message.Key,
+				Value:     string(message.Value),
+				Headers:   traceHeaders(ctx, message.Headers),
+				MessageID: message.MessageID,
+				Timestamp: message.Timestamp,
+			})
+		}
 
-	for _, message := range messages {
-		result, err := p.Send(ctx, topic, message)
+		resp, err := p.appService.ProduceBatch(ctx, req)
 		if err != nil {
-			p.logger.Error("Failed to send message in batch",
-				logging.Field{Key: "error", Value: err},
-				logging.Field{Key: "key", Value: message.Key})
-			failureCount++
-			continue
+			span.RecordError(err)
+			p.logger.Error("Failed to send batch",
+				logging.Field{Key: "sent", Value: start},
+				logging.Field{Key: "total", Value: len(messages)},
+				logging.Field{Key: "error", Value: err})
+			for i := start; i < len(messages); i++ {
+				batchResult.Failures = append(batchResult.Failures, &BatchSendFailure{Index: i, Error: err.Error()})
+				batchResult.FailureCount++
+			}
+			return batchResult, err
 		}
-		results = append(results, result)
-		successCount++
-	}
 
-	batchResult := &BatchSendResult{
-		Results:      results,
-		SuccessCount: successCount,
-		FailureCount: failureCount,
+		for i, result := range resp.Results {
+			if !result.Success {
+				batchResult.Failures = append(batchResult.Failures, &BatchSendFailure{
+					Index: start + i,
+					Error: result.Error,
+				})
+				batchResult.FailureCount++
+				continue
+			}
+			batchResult.Results = append(batchResult.Results, &SendResult{
+				MessageID: result.MessageID,
+				Offset:    result.Offset,
+				Partition: result.Partition,
+			})
+			batchResult.SuccessCount++
+		}
 	}
 
 	p.logger.Info("Batch send completed",
 		logging.Field{Key: "total", Value: len(messages)},
-		logging.Field{Key: "success", Value: successCount},
-		logging.Field{Key: "failure", Value: failureCount})
+		logging.Field{Key: "success", Value: batchResult.SuccessCount},
+		logging.Field{Key: "failure", Value: batchResult.FailureCount})
 
 	return batchResult, nil
 }
 
+// startSpan 开始发送消息的producer span
+func (p *Producer) startSpan(ctx context.Context, topic string, count int) (context.Context, tracing.Span) {
+	return p.tracer.Start(ctx, "send "+topic, tracing.SpanKindProducer,
+		tracing.Attribute{Key: "messaging.system", Value: "fluvio"},
+		tracing.Attribute{Key: "messaging.destination.name", Value: topic},
+		tracing.Attribute{Key: "messaging.batch.message_count", Value: count})
+}
+
+// traceHeaders 将ctx中的trace上下文写入消息头的副本，不修改调用方的map
+// ctx中没有有效span时原样返回，消息中已有的traceparent得以保留
+func traceHeaders(ctx context.Context, headers map[string]string) map[string]string {
+	if _, ok := tracing.SpanContextFromContext(ctx); !ok {
+		return headers
+	}
+	copied := make(map[string]string, len(headers)+2)
+	for k, v := range headers {
+		copied[k] = v
+	}
+	tracing.Inject(ctx, copied)
+	return copied
+}
+
 // SendString 发送字符串消息（便捷方法）
 func (p *Producer) SendString(ctx context.Context, topic, key, value string) (*SendResult, error) {
 	message := &Message{
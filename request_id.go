@@ -0,0 +1,28 @@
+package fluvio
+
+import (
+	"context"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/grpc"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// ContextWithRequestID 为ctx下的所有RPC指定请求ID，以x-request-id元数据发送
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return grpc.ContextWithRequestID(ctx, id)
+}
+
+// RequestIDFromContext 获取ctx中的请求ID
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	return grpc.RequestIDFromContext(ctx)
+}
+
+// NewRequestID 生成请求ID
+func NewRequestID() string {
+	return grpc.NewRequestID()
+}
+
+// RequestIDFromError 获取RPC失败时错误中携带的请求ID，没有时返回空串
+func RequestIDFromError(err error) string {
+	return errors.RequestID(err)
+}
@@ -0,0 +1,324 @@
+package fluvio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// MaxWasmSize LoadWasmFile允许的最大模块大小
+const MaxWasmSize = 32 * 1024 * 1024
+
+// wasmMagic WebAssembly二进制模块的魔数和版本号
+var (
+	wasmMagic   = []byte{0x00, 0x61, 0x73, 0x6d}
+	wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+)
+
+// SmartModuleKind SmartModule输入/输出类型
+type SmartModuleKind string
+
+// SmartModule输入/输出类型常量
+const (
+	SmartModuleStream SmartModuleKind = "stream"
+	SmartModuleTable  SmartModuleKind = "table"
+)
+
+// SmartModuleParameter SmartModule参数声明
+type SmartModuleParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Optional    bool   `json:"optional"`
+}
+
+// SmartModuleSpec SmartModule规格
+type SmartModuleSpec struct {
+	Name        string                  `json:"name"`
+	Version     string                  `json:"version,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	InputKind   SmartModuleKind         `json:"input_kind,omitempty"`  // 默认stream
+	OutputKind  SmartModuleKind         `json:"output_kind,omitempty"` // 默认stream
+	Parameters  []*SmartModuleParameter `json:"parameters,omitempty"`
+}
+
+// NewSmartModuleSpec 创建SmartModule规格，输入输出类型默认为stream
+func NewSmartModuleSpec(name string) *SmartModuleSpec {
+	return &SmartModuleSpec{
+		Name:       name,
+		InputKind:  SmartModuleStream,
+		OutputKind: SmartModuleStream,
+	}
+}
+
+// WithVersion 设置版本
+func (s *SmartModuleSpec) WithVersion(version string) *SmartModuleSpec {
+	s.Version = version
+	return s
+}
+
+// WithDescription 设置描述
+func (s *SmartModuleSpec) WithDescription(description string) *SmartModuleSpec {
+	s.Description = description
+	return s
+}
+
+// WithInputKind 设置输入类型
+func (s *SmartModuleSpec) WithInputKind(kind SmartModuleKind) *SmartModuleSpec {
+	s.InputKind = kind
+	return s
+}
+
+// WithOutputKind 设置输出类型
+func (s *SmartModuleSpec) WithOutputKind(kind SmartModuleKind) *SmartModuleSpec {
+	s.OutputKind = kind
+	return s
+}
+
+// WithParameter 添加参数声明
+func (s *SmartModuleSpec) WithParameter(name, description string, optional bool) *SmartModuleSpec {
+	s.Parameters = append(s.Parameters, &SmartModuleParameter{
+		Name:        name,
+		Description: description,
+		Optional:    optional,
+	})
+	return s
+}
+
+// Validate 验证规格
+func (s *SmartModuleSpec) Validate() error {
+	if s.Name == "" {
+		return errors.New(errors.ErrInvalidArgument, "smart module name cannot be empty")
+	}
+	for _, kind := range []SmartModuleKind{s.InputKind, s.OutputKind} {
+		if kind != "" && kind != SmartModuleStream && kind != SmartModuleTable {
+			return errors.New(errors.ErrInvalidArgument, fmt.Sprintf("unknown smart module kind: %s", kind))
+		}
+	}
+	seen := make(map[string]bool, len(s.Parameters))
+	for _, param := range s.Parameters {
+		if param.Name == "" {
+			return errors.New(errors.ErrInvalidArgument, "smart module parameter name cannot be empty")
+		}
+		if seen[param.Name] {
+			return errors.New(errors.ErrInvalidArgument, fmt.Sprintf("duplicate smart module parameter: %s", param.Name))
+		}
+		seen[param.Name] = true
+	}
+	return nil
+}
+
+// toDTO 转换为DTO
+func (s *SmartModuleSpec) toDTO() *dtos.SmartModuleDTO {
+	dto := &dtos.SmartModuleDTO{
+		Name:        s.Name,
+		Version:     s.Version,
+		Description: s.Description,
+		InputKind:   string(s.InputKind),
+		OutputKind:  string(s.OutputKind),
+	}
+	for _, param := range s.Parameters {
+		dto.Parameters = append(dto.Parameters, &dtos.SmartModuleParameterDTO{
+			Name:        param.Name,
+			Description: param.Description,
+			Optional:    param.Optional,
+		})
+	}
+	return dto
+}
+
+// smartModuleSpecFromDTO 从DTO转换为规格
+func smartModuleSpecFromDTO(dto *dtos.SmartModuleDTO) *SmartModuleSpec {
+	spec := &SmartModuleSpec{
+		Name:        dto.Name,
+		Version:     dto.Version,
+		Description: dto.Description,
+		InputKind:   SmartModuleKind(dto.InputKind),
+		OutputKind:  SmartModuleKind(dto.OutputKind),
+	}
+	for _, param := range dto.Parameters {
+		spec.Parameters = append(spec.Parameters, &SmartModuleParameter{
+			Name:        param.Name,
+			Description: param.Description,
+			Optional:    param.Optional,
+		})
+	}
+	return spec
+}
+
+// ValidateWasm 检查数据是否为WebAssembly二进制模块（魔数和版本号）
+func ValidateWasm(code []byte) error {
+	if len(code) < len(wasmMagic)+len(wasmVersion) {
+		return errors.New(errors.ErrValidation, "wasm module too small")
+	}
+	if !bytes.Equal(code[:4], wasmMagic) {
+		return errors.New(errors.ErrValidation, "invalid wasm magic header")
+	}
+	if !bytes.Equal(code[4:8], wasmVersion) {
+		return errors.New(errors.ErrValidation, "unsupported wasm binary version")
+	}
+	return nil
+}
+
+// LoadWasmFile 从文件读取WebAssembly模块，并检查大小和文件头
+func LoadWasmFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidArgument, "failed to open wasm file", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidArgument, "failed to stat wasm file", err)
+	}
+	if info.IsDir() {
+		return nil, errors.New(errors.ErrInvalidArgument, fmt.Sprintf("%s is a directory", path))
+	}
+	if info.Size() > MaxWasmSize {
+		return nil, errors.New(errors.ErrResourceLimit,
+			fmt.Sprintf("wasm file %s is %d bytes, exceeds limit of %d bytes", path, info.Size(), MaxWasmSize))
+	}
+
+	// 文件可能在Stat之后增长，读取时同样限制大小
+	code, err := io.ReadAll(io.LimitReader(file, MaxWasmSize+1))
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidArgument, "failed to read wasm file", err)
+	}
+	if len(code) > MaxWasmSize {
+		return nil, errors.New(errors.ErrResourceLimit, fmt.Sprintf("wasm file %s exceeds limit of %d bytes", path, MaxWasmSize))
+	}
+
+	if err := ValidateWasm(code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// Describe 获取SmartModule规格
+func (s *SmartModuleManager) Describe(ctx context.Context, name string) (*SmartModuleSpec, error) {
+	if !*s.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	s.logger.Debug("Describing SmartModule", logging.Field{Key: "name", Value: name})
+
+	resp, err := s.appService.DescribeSmartModule(ctx, &dtos.DescribeSmartModuleRequest{Name: name})
+	if err != nil {
+		s.logger.Error("Failed to describe SmartModule", logging.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	s.logger.Info("SmartModule described successfully", logging.Field{Key: "name", Value: name})
+	return smartModuleSpecFromDTO(resp.Module), nil
+}
+
+// CreateWithSpec 使用完整规格创建SmartModule
+func (s *SmartModuleManager) CreateWithSpec(ctx context.Context, spec *SmartModuleSpec, wasmCode []byte) error {
+	if !*s.connected {
+		return errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if spec == nil {
+		return errors.New(errors.ErrInvalidArgument, "smart module spec cannot be nil")
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	if err := ValidateWasm(wasmCode); err != nil {
+		return err
+	}
+
+	s.logger.Debug("Creating SmartModule",
+		logging.Field{Key: "name", Value: spec.Name},
+		logging.Field{Key: "version", Value: spec.Version})
+
+	resp, err := s.appService.CreateSmartModule(ctx, &dtos.CreateSmartModuleRequest{
+		Name:     spec.Name,
+		Spec:     spec.toDTO(),
+		WasmCode: wasmCode,
+	})
+	if err != nil {
+		s.logger.Error("Failed to create SmartModule", logging.Field{Key: "error", Value: err})
+		return err
+	}
+
+	if !resp.Success {
+		return errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	s.logger.Info("SmartModule created successfully", logging.Field{Key: "name", Value: spec.Name})
+	return nil
+}
+
+// CreateFromFile 从.wasm文件创建SmartModule
+func (s *SmartModuleManager) CreateFromFile(ctx context.Context, spec *SmartModuleSpec, path string) error {
+	wasmCode, err := LoadWasmFile(path)
+	if err != nil {
+		return err
+	}
+	return s.CreateWithSpec(ctx, spec, wasmCode)
+}
+
+// Update 更新SmartModule的规格和/或Wasm代码，spec和wasmCode不能同时为空
+func (s *SmartModuleManager) Update(ctx context.Context, name string, spec *SmartModuleSpec, wasmCode []byte) error {
+	if !*s.connected {
+		return errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if name == "" {
+		return errors.New(errors.ErrInvalidArgument, "smart module name cannot be empty")
+	}
+	if spec == nil && len(wasmCode) == 0 {
+		return errors.New(errors.ErrInvalidArgument, "either spec or wasm code must be provided")
+	}
+
+	req := &dtos.UpdateSmartModuleRequest{Name: name}
+	if spec != nil {
+		// 使用副本，不修改调用方的规格
+		desired := *spec
+		if desired.Name == "" {
+			desired.Name = name
+		}
+		if desired.Name != name {
+			return errors.New(errors.ErrInvalidArgument, "smart module cannot be renamed")
+		}
+		if err := desired.Validate(); err != nil {
+			return err
+		}
+		req.Spec = desired.toDTO()
+	}
+	if len(wasmCode) > 0 {
+		if err := ValidateWasm(wasmCode); err != nil {
+			return err
+		}
+		req.WasmCode = wasmCode
+	}
+
+	s.logger.Debug("Updating SmartModule",
+		logging.Field{Key: "name", Value: name},
+		logging.Field{Key: "update_spec", Value: spec != nil},
+		logging.Field{Key: "update_wasm", Value: len(wasmCode) > 0})
+
+	resp, err := s.appService.UpdateSmartModule(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to update SmartModule", logging.Field{Key: "error", Value: err})
+		return err
+	}
+
+	if !resp.Success {
+		return errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	s.logger.Info("SmartModule updated successfully", logging.Field{Key: "name", Value: name})
+	return nil
+}
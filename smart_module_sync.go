@@ -0,0 +1,359 @@
+package fluvio
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// SmartModuleManifest SmartModule部署清单
+//
+// 清单文件可以是YAML或JSON，格式如下：
+//
+//	modules:
+//	  - name: my-filter
+//	    version: 1.2.0
+//	    description: 过滤无效事件
+//	    input_kind: stream
+//	    output_kind: stream
+//	    parameters:
+//	      - name: threshold
+//	        optional: true
+//	    wasm: ./my_filter.wasm
+//
+// wasm路径相对于清单文件所在目录。同步时Wasm代码不参与比较，
+// 只修改Wasm时需提升version或使用ForceWasm才会重新上传
+type SmartModuleManifest struct {
+	Modules []*SmartModuleManifestEntry `json:"modules" yaml:"modules"`
+}
+
+// SmartModuleManifestEntry 清单中的单个SmartModule
+type SmartModuleManifestEntry struct {
+	Name        string                  `json:"name" yaml:"name"`
+	Version     string                  `json:"version,omitempty" yaml:"version,omitempty"`
+	Description string                  `json:"description,omitempty" yaml:"description,omitempty"`
+	InputKind   SmartModuleKind         `json:"input_kind,omitempty" yaml:"input_kind,omitempty"`
+	OutputKind  SmartModuleKind         `json:"output_kind,omitempty" yaml:"output_kind,omitempty"`
+	Parameters  []*SmartModuleParameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Wasm        string                  `json:"wasm" yaml:"wasm"`
+}
+
+// Spec 转换为SmartModule规格，未指定的输入输出类型默认为stream
+func (e *SmartModuleManifestEntry) Spec() *SmartModuleSpec {
+	spec := NewSmartModuleSpec(e.Name).
+		WithVersion(e.Version).
+		WithDescription(e.Description)
+	if e.InputKind != "" {
+		spec.InputKind = e.InputKind
+	}
+	if e.OutputKind != "" {
+		spec.OutputKind = e.OutputKind
+	}
+	for _, param := range e.Parameters {
+		spec.WithParameter(param.Name, param.Description, param.Optional)
+	}
+	return spec
+}
+
+// LoadSmartModuleManifest 读取清单文件，path为目录时合并目录下所有 .yaml/.yml/.json 文件
+func LoadSmartModuleManifest(path string) (*SmartModuleManifest, error) {
+	files, err := manifestFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &SmartModuleManifest{}
+	seen := make(map[string]string)
+	for _, file := range files {
+		part, err := readSmartModuleManifest(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range part.Modules {
+			if prev, ok := seen[entry.Name]; ok {
+				return nil, errors.New(errors.ErrValidation,
+					fmt.Sprintf("smart module %s defined in both %s and %s", entry.Name, prev, file))
+			}
+			seen[entry.Name] = file
+			manifest.Modules = append(manifest.Modules, entry)
+		}
+	}
+	return manifest, nil
+}
+
+// readSmartModuleManifest 读取单个清单文件，并将wasm路径解析为相对于清单文件的路径
+func readSmartModuleManifest(file string) (*SmartModuleManifest, error) {
+	manifest := &SmartModuleManifest{}
+	if err := decodeManifestFile(file, manifest); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(file)
+	for _, entry := range manifest.Modules {
+		if err := entry.Spec().Validate(); err != nil {
+			return nil, errors.Wrap(errors.ErrValidation, fmt.Sprintf("invalid smart module in %s", file), err)
+		}
+		if entry.Wasm == "" {
+			return nil, errors.New(errors.ErrValidation, fmt.Sprintf("smart module %s in %s has no wasm path", entry.Name, file))
+		}
+		if !filepath.IsAbs(entry.Wasm) {
+			entry.Wasm = filepath.Join(dir, entry.Wasm)
+		}
+	}
+	return manifest, nil
+}
+
+// SyncAction 同步动作
+type SyncAction string
+
+// 同步动作常量
+const (
+	SyncCreate    SyncAction = "create"
+	SyncUpdate    SyncAction = "update"
+	SyncDelete    SyncAction = "delete"
+	SyncUnchanged SyncAction = "unchanged"
+)
+
+// SmartModuleSyncOptions SmartModule同步选项
+type SmartModuleSyncOptions struct {
+	Prune      bool `json:"prune,omitempty"`        // 删除清单中不存在的SmartModule
+	DryRun     bool `json:"dry_run,omitempty"`      // 只计算计划，不执行
+	ForceWasm  bool `json:"force_wasm,omitempty"`   // 规格未变化时也重新上传Wasm代码
+	StopOnFail bool `json:"stop_on_fail,omitempty"` // 遇到第一个失败即停止
+}
+
+// SmartModuleChange 单个SmartModule的变更
+type SmartModuleChange struct {
+	Action  SyncAction       `json:"action"`
+	Name    string           `json:"name"`
+	Current *SmartModuleSpec `json:"current,omitempty"`
+	Desired *SmartModuleSpec `json:"desired,omitempty"`
+	Diffs   []string         `json:"diffs,omitempty"`
+	Applied bool             `json:"applied"`
+	Error   string           `json:"error,omitempty"`
+
+	wasm []byte
+}
+
+// SmartModulePlan SmartModule同步计划
+type SmartModulePlan struct {
+	DryRun  bool                 `json:"dry_run"`
+	Changes []*SmartModuleChange `json:"changes"`
+}
+
+// HasChanges 检查计划中是否有需要执行的变更
+func (p *SmartModulePlan) HasChanges() bool {
+	for _, change := range p.Changes {
+		if change.Action != SyncUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// String 以diff形式输出计划，存在未变化的SmartModule时提示Wasm代码未参与比较
+func (p *SmartModulePlan) String() string {
+	var b strings.Builder
+	unchanged := false
+	for _, change := range p.Changes {
+		switch change.Action {
+		case SyncUnchanged:
+			unchanged = true
+		case SyncCreate:
+			fmt.Fprintf(&b, "+ %s (version %s)\n", change.Name, change.Desired.Version)
+		case SyncUpdate:
+			fmt.Fprintf(&b, "~ %s\n", change.Name)
+			for _, diff := range change.Diffs {
+				fmt.Fprintf(&b, "    %s\n", diff)
+			}
+		case SyncDelete:
+			fmt.Fprintf(&b, "- %s\n", change.Name)
+		}
+	}
+	if b.Len() == 0 {
+		b.WriteString("no changes\n")
+	}
+	if unchanged {
+		b.WriteString("note: wasm not compared; bump version or use ForceWasm to re-upload\n")
+	}
+	return b.String()
+}
+
+// Plan 比较清单与服务端现有SmartModule，计算变更计划
+// 清单中的Wasm文件会在计划阶段读取并校验，但只比较规格，不比较Wasm代码：
+// 规格未变化时计划为unchanged，需提升version或设置ForceWasm才会重新上传
+func (s *SmartModuleManager) Plan(ctx context.Context, manifest *SmartModuleManifest, opts *SmartModuleSyncOptions) (*SmartModulePlan, error) {
+	if !*s.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if manifest == nil {
+		return nil, errors.New(errors.ErrInvalidArgument, "manifest cannot be nil")
+	}
+	if opts == nil {
+		opts = &SmartModuleSyncOptions{}
+	}
+
+	existing, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deployed := make(map[string]bool, len(existing))
+	for _, module := range existing {
+		deployed[module.Name] = true
+	}
+
+	plan := &SmartModulePlan{DryRun: opts.DryRun}
+	desired := make(map[string]bool, len(manifest.Modules))
+
+	for _, entry := range manifest.Modules {
+		desired[entry.Name] = true
+		spec := entry.Spec()
+
+		wasm, err := LoadWasmFile(entry.Wasm)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrValidation, fmt.Sprintf("smart module %s", entry.Name), err)
+		}
+
+		change := &SmartModuleChange{Name: entry.Name, Desired: spec, wasm: wasm}
+		if !deployed[entry.Name] {
+			change.Action = SyncCreate
+			plan.Changes = append(plan.Changes, change)
+			continue
+		}
+
+		current, err := s.Describe(ctx, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		change.Current = current
+		change.Diffs = diffSmartModuleSpecs(current, spec)
+
+		switch {
+		case len(change.Diffs) > 0:
+			change.Action = SyncUpdate
+		case opts.ForceWasm:
+			change.Action = SyncUpdate
+			change.Diffs = []string{"wasm: re-upload"}
+		default:
+			change.Action = SyncUnchanged
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if opts.Prune {
+		for _, module := range existing {
+			if !desired[module.Name] {
+				plan.Changes = append(plan.Changes, &SmartModuleChange{Action: SyncDelete, Name: module.Name})
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool { return plan.Changes[i].Name < plan.Changes[j].Name })
+
+	s.logger.Debug("SmartModule sync plan computed",
+		logging.Field{Key: "changes", Value: len(plan.Changes)},
+		logging.Field{Key: "prune", Value: opts.Prune})
+	return plan, nil
+}
+
+// Apply 执行同步计划，DryRun计划不会执行任何变更
+func (s *SmartModuleManager) Apply(ctx context.Context, plan *SmartModulePlan, opts *SmartModuleSyncOptions) error {
+	if plan == nil {
+		return errors.New(errors.ErrInvalidArgument, "plan cannot be nil")
+	}
+	if opts == nil {
+		opts = &SmartModuleSyncOptions{}
+	}
+	if plan.DryRun {
+		return nil
+	}
+
+	failed := 0
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Action {
+		case SyncCreate:
+			err = s.CreateWithSpec(ctx, change.Desired, change.wasm)
+		case SyncUpdate:
+			err = s.Update(ctx, change.Name, change.Desired, change.wasm)
+		case SyncDelete:
+			err = s.Delete(ctx, change.Name)
+		default:
+			continue
+		}
+
+		if err != nil {
+			change.Error = err.Error()
+			failed++
+			if opts.StopOnFail {
+				break
+			}
+			continue
+		}
+		change.Applied = true
+	}
+
+	if failed > 0 {
+		return errors.New(errors.ErrOperation, fmt.Sprintf("%d smart module changes failed", failed))
+	}
+
+	s.logger.Info("SmartModule sync plan applied", logging.Field{Key: "changes", Value: len(plan.Changes)})
+	return nil
+}
+
+// Sync 根据清单计算并执行同步计划
+func (s *SmartModuleManager) Sync(ctx context.Context, manifest *SmartModuleManifest, opts *SmartModuleSyncOptions) (*SmartModulePlan, error) {
+	plan, err := s.Plan(ctx, manifest, opts)
+	if err != nil {
+		return nil, err
+	}
+	return plan, s.Apply(ctx, plan, opts)
+}
+
+// diffSmartModuleSpecs 比较两个规格，返回有差异的字段描述
+func diffSmartModuleSpecs(current, desired *SmartModuleSpec) []string {
+	var diffs []string
+	field := func(name, from, to string) {
+		if from != to {
+			diffs = append(diffs, fmt.Sprintf("%s: %q -> %q", name, from, to))
+		}
+	}
+
+	field("version", current.Version, desired.Version)
+	field("description", current.Description, desired.Description)
+	field("input_kind", string(kindOrDefault(current.InputKind)), string(kindOrDefault(desired.InputKind)))
+	field("output_kind", string(kindOrDefault(current.OutputKind)), string(kindOrDefault(desired.OutputKind)))
+	field("parameters", formatParameters(current.Parameters), formatParameters(desired.Parameters))
+	return diffs
+}
+
+// kindOrDefault 未设置的类型按stream处理
+func kindOrDefault(kind SmartModuleKind) SmartModuleKind {
+	if kind == "" {
+		return SmartModuleStream
+	}
+	return kind
+}
+
+// formatParameters 将参数列表格式化为按名称排序的字符串，便于比较
+func formatParameters(params []*SmartModuleParameter) string {
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		part := param.Name
+		if param.Optional {
+			part += "?"
+		}
+		if param.Description != "" {
+			part += "(" + param.Description + ")"
+		}
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
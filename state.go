@@ -0,0 +1,256 @@
+package fluvio
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	pb "github.com/iwen-conf/fluvio_grpc_client/proto/fluvio_service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// State 客户端连接状态
+type State int
+
+// 连接状态常量
+const (
+	StateDisconnected     State = iota // 未连接或已关闭
+	StateIdle                          // 连接空闲，下次调用时自动建立传输
+	StateConnecting                    // 正在建立或重新建立连接
+	StateReady                         // 连接就绪
+	StateUnhealthy                     // 连接就绪但健康探测失败
+	StateTransientFailure              // 连接失败，正在退避重试
+)
+
+// String 返回状态字符串
+func (s State) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateConnecting:
+		return "connecting"
+	case StateReady:
+		return "ready"
+	case StateUnhealthy:
+		return "unhealthy"
+	case StateTransientFailure:
+		return "transient_failure"
+	default:
+		return "disconnected"
+	}
+}
+
+// stateTracker 综合gRPC连接状态、客户端生命周期和健康探测结果得出State
+type stateTracker struct {
+	logger logging.Logger
+	source func() connectivity.State // 当前gRPC连接状态，用于Connect后立即同步
+
+	mu        sync.Mutex
+	active    bool // Connect成功且尚未Close
+	unhealthy bool
+	conn      connectivity.State
+	state     State
+	changed   chan struct{} // 状态变化时关闭并替换
+	listeners map[int]func(old, new State)
+	nextID    int
+
+	notifyMu sync.Mutex // 保证监听者按变化顺序收到通知
+}
+
+// newStateTracker 创建状态跟踪器
+func newStateTracker(logger logging.Logger, source func() connectivity.State) *stateTracker {
+	return &stateTracker{
+		logger:    logger,
+		source:    source,
+		conn:      connectivity.Shutdown,
+		state:     StateDisconnected,
+		changed:   make(chan struct{}),
+		listeners: make(map[int]func(old, new State)),
+	}
+}
+
+// update 修改输入并在状态变化时通知监听者
+func (t *stateTracker) update(modify func()) {
+	t.notifyMu.Lock()
+	defer t.notifyMu.Unlock()
+
+	t.mu.Lock()
+	modify()
+	old, next := t.state, t.compute()
+	if old == next {
+		t.mu.Unlock()
+		return
+	}
+	t.state = next
+	close(t.changed)
+	t.changed = make(chan struct{})
+	listeners := make([]func(old, new State), 0, len(t.listeners))
+	for _, fn := range t.listeners {
+		listeners = append(listeners, fn)
+	}
+	t.mu.Unlock()
+
+	t.logger.Info("连接状态变化",
+		logging.Field{Key: "from", Value: old.String()},
+		logging.Field{Key: "to", Value: next.String()})
+	for _, fn := range listeners {
+		fn(old, next)
+	}
+}
+
+// compute 计算当前状态；调用方需持有锁
+func (t *stateTracker) compute() State {
+	if !t.active {
+		return StateDisconnected
+	}
+	switch t.conn {
+	case connectivity.Ready:
+		if t.unhealthy {
+			return StateUnhealthy
+		}
+		return StateReady
+	case connectivity.Idle:
+		return StateIdle
+	case connectivity.TransientFailure:
+		return StateTransientFailure
+	default:
+		return StateConnecting
+	}
+}
+
+// setConn gRPC连接状态变化；连接不再就绪时清除健康探测结果，恢复后由下次探测重新判断
+func (t *stateTracker) setConn(state connectivity.State) {
+	t.update(func() {
+		t.conn = state
+		if state != connectivity.Ready {
+			t.unhealthy = false
+		}
+	})
+}
+
+// setActive 客户端连接或关闭
+func (t *stateTracker) setActive(active bool) {
+	t.update(func() {
+		t.active = active
+		t.unhealthy = false
+		if active && t.source != nil {
+			t.conn = t.source()
+		}
+	})
+}
+
+// setHealth 记录健康探测结果，仅在连接就绪时生效
+func (t *stateTracker) setHealth(err error) {
+	t.update(func() { t.unhealthy = err != nil && t.conn == connectivity.Ready })
+}
+
+// current 返回当前状态和状态变化时关闭的通道
+func (t *stateTracker) current() (State, <-chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state, t.changed
+}
+
+// State 获取当前连接状态
+func (c *Client) State() State {
+	state, _ := c.state.current()
+	return state
+}
+
+// WaitForStateChange 等待状态离开from，状态已变化时返回true，ctx结束时返回false
+func (c *Client) WaitForStateChange(ctx context.Context, from State) bool {
+	for {
+		state, changed := c.state.current()
+		if state != from {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-changed:
+		}
+	}
+}
+
+// OnStateChange 注册状态变化回调，返回的函数用于取消注册
+// 回调按变化顺序串行调用，不应长时间阻塞，也不能在回调中调用Connect或Close
+func (c *Client) OnStateChange(fn func(old, new State)) (cancel func()) {
+	t := c.state
+	t.mu.Lock()
+	id := t.nextID
+	t.nextID++
+	t.listeners[id] = fn
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		delete(t.listeners, id)
+		t.mu.Unlock()
+	}
+}
+
+// startHealthProbe 按间隔调用HealthCheck，服务端报告不健康或调用失败时状态变为StateUnhealthy
+func (c *Client) startHealthProbe(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.probeCancel = cancel
+	c.probeDone = make(chan struct{})
+
+	go func() {
+		defer close(c.probeDone)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			err := c.probe(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				c.logger.Debug("健康探测失败", logging.Field{Key: "error", Value: err})
+			}
+			c.state.setHealth(err)
+		}
+	}()
+}
+
+// stopHealthProbe 停止健康探测
+func (c *Client) stopHealthProbe() {
+	if c.probeCancel == nil {
+		return
+	}
+	c.probeCancel()
+	<-c.probeDone
+	c.probeCancel = nil
+	c.probeDone = nil
+}
+
+// probe 执行一次健康探测；服务端未实现HealthCheck时视为健康
+func (c *Client) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Connection.RequestTimeout)
+	defer cancel()
+
+	resp, err := c.grpcClient.HealthCheck(ctx, &pb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	switch resp.GetStatus() {
+	case pb.HealthStatus_HEALTHY, pb.HealthStatus_DEGRADED:
+		return nil
+	default:
+		return fmt.Errorf("server reported %s: %s", resp.GetStatus(), resp.GetMessage())
+	}
+}
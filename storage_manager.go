@@ -0,0 +1,320 @@
+package fluvio
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
+	"github.com/iwen-conf/fluvio_grpc_client/application/services"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// StorageType 服务端存储类型
+type StorageType string
+
+// 存储类型常量
+const (
+	StorageMemory  StorageType = "memory"
+	StorageMongoDB StorageType = "mongodb"
+)
+
+// StorageManager 存储管理器
+type StorageManager struct {
+	appService *services.FluvioApplicationService
+	logger     logging.Logger
+	connected  *bool
+}
+
+// StorageStatus 存储状态
+type StorageStatus struct {
+	PersistenceEnabled bool                    `json:"persistence_enabled"`
+	StorageType        StorageType             `json:"storage_type"`
+	ConsumerGroups     uint64                  `json:"consumer_groups"`
+	ConsumerOffsets    uint64                  `json:"consumer_offsets"`
+	SmartModules       uint64                  `json:"smart_modules"`
+	ConnectionStatus   string                  `json:"connection_status"`
+	Connections        *StorageConnectionStats `json:"connections,omitempty"` // 仅在includeDetails为true时返回
+	Database           *StorageDatabaseInfo    `json:"database,omitempty"`    // 仅在includeDetails为true时返回
+	CheckedAt          time.Time               `json:"checked_at"`
+}
+
+// StorageConnectionStats 存储连接统计
+type StorageConnectionStats struct {
+	Current      uint32 `json:"current"`
+	Available    uint32 `json:"available"`
+	TotalCreated uint32 `json:"total_created"`
+}
+
+// StorageDatabaseInfo 存储数据库信息
+type StorageDatabaseInfo struct {
+	Name             string `json:"name"`
+	Collections      uint32 `json:"collections"`
+	DataSizeBytes    uint64 `json:"data_size_bytes"`
+	StorageSizeBytes uint64 `json:"storage_size_bytes"`
+	Indexes          uint32 `json:"indexes"`
+	IndexSizeBytes   uint64 `json:"index_size_bytes"`
+}
+
+// StorageMetricsOptions 获取存储指标选项
+type StorageMetricsOptions struct {
+	IncludeHistory bool   `json:"include_history,omitempty"`
+	HistoryLimit   uint32 `json:"history_limit,omitempty"`
+}
+
+// StorageMetrics 存储指标
+type StorageMetrics struct {
+	Current     *StorageMetricsSample   `json:"current,omitempty"`
+	History     []*StorageMetricsSample `json:"history,omitempty"`
+	Health      *StorageHealth          `json:"health,omitempty"`
+	Alerts      []string                `json:"alerts,omitempty"`
+	CollectedAt time.Time               `json:"collected_at"`
+}
+
+// StorageMetricsSample 某一时刻的存储性能指标
+type StorageMetricsSample struct {
+	StorageType         StorageType   `json:"storage_type"`
+	ResponseTime        time.Duration `json:"response_time"`
+	OperationsPerSecond float64       `json:"operations_per_second"`
+	ErrorRate           float64       `json:"error_rate"`
+	ConnectionPoolUsage float64       `json:"connection_pool_usage"` // 百分比
+	MemoryUsageMB       uint64        `json:"memory_usage_mb"`
+	DiskUsageMB         uint64        `json:"disk_usage_mb"`
+	Timestamp           time.Time     `json:"timestamp"`
+}
+
+// StorageHealth 存储健康状态
+type StorageHealth struct {
+	Status       string        `json:"status"` // healthy, warning, critical, unknown
+	ResponseTime time.Duration `json:"response_time"`
+	Error        string        `json:"error,omitempty"`
+	CheckedAt    time.Time     `json:"checked_at"`
+}
+
+// IsHealthy 检查存储是否健康
+func (h *StorageHealth) IsHealthy() bool {
+	return h.Status == "healthy"
+}
+
+// MigrateStorageOptions 存储迁移选项
+type MigrateStorageOptions struct {
+	Source StorageType `json:"source"`
+	Target StorageType `json:"target"`
+	Verify bool        `json:"verify,omitempty"` // 迁移后校验数据
+	Force  bool        `json:"force,omitempty"`  // 覆盖目标存储中已有的数据
+	// Confirm 必须显式设置为true，防止误操作
+	Confirm bool `json:"confirm"`
+}
+
+// MigrationResult 存储迁移结果
+type MigrationResult struct {
+	Success                 bool      `json:"success"`
+	VerificationPassed      bool      `json:"verification_passed"`
+	ConsumerGroupsMigrated  uint64    `json:"consumer_groups_migrated"`
+	ConsumerOffsetsMigrated uint64    `json:"consumer_offsets_migrated"`
+	SmartModulesMigrated    uint64    `json:"smart_modules_migrated"`
+	TotalMigrated           uint64    `json:"total_migrated"`
+	Errors                  []string  `json:"errors,omitempty"`
+	CompletedAt             time.Time `json:"completed_at"`
+}
+
+// Storage 获取存储管理器
+func (a *AdminManager) Storage() *StorageManager {
+	return &StorageManager{
+		appService: a.appService,
+		logger:     a.logger,
+		connected:  a.connected,
+	}
+}
+
+// Status 获取存储状态
+func (s *StorageManager) Status(ctx context.Context, includeDetails bool) (*StorageStatus, error) {
+	if !*s.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	s.logger.Debug("Getting storage status")
+
+	resp, err := s.appService.GetStorageStatus(ctx, &dtos.GetStorageStatusRequest{IncludeDetails: includeDetails})
+	if err != nil {
+		s.logger.Error("Failed to get storage status", logging.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	status := &StorageStatus{
+		PersistenceEnabled: resp.PersistenceEnabled,
+		CheckedAt:          resp.CheckedAt,
+	}
+	if stats := resp.Stats; stats != nil {
+		status.StorageType = StorageType(stats.StorageType)
+		status.ConsumerGroups = stats.ConsumerGroups
+		status.ConsumerOffsets = stats.ConsumerOffsets
+		status.SmartModules = stats.SmartModules
+		status.ConnectionStatus = stats.ConnectionStatus
+		if conn := stats.ConnectionStats; conn != nil {
+			status.Connections = &StorageConnectionStats{
+				Current:      conn.CurrentConnections,
+				Available:    conn.AvailableConnections,
+				TotalCreated: conn.TotalCreatedConnections,
+			}
+		}
+		if db := stats.DatabaseInfo; db != nil {
+			status.Database = &StorageDatabaseInfo{
+				Name:             db.Name,
+				Collections:      db.Collections,
+				DataSizeBytes:    db.DataSize,
+				StorageSizeBytes: db.StorageSize,
+				Indexes:          db.Indexes,
+				IndexSizeBytes:   db.IndexSize,
+			}
+		}
+	}
+
+	s.logger.Info("Storage status retrieved successfully", logging.Field{Key: "storage_type", Value: status.StorageType})
+	return status, nil
+}
+
+// Metrics 获取存储指标，包括当前指标、可选的历史指标、健康状态和告警
+func (s *StorageManager) Metrics(ctx context.Context, opts *StorageMetricsOptions) (*StorageMetrics, error) {
+	if !*s.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if opts == nil {
+		opts = &StorageMetricsOptions{}
+	}
+
+	s.logger.Debug("Getting storage metrics", logging.Field{Key: "include_history", Value: opts.IncludeHistory})
+
+	resp, err := s.appService.GetStorageMetrics(ctx, &dtos.GetStorageMetricsRequest{
+		IncludeHistory: opts.IncludeHistory,
+		HistoryLimit:   opts.HistoryLimit,
+	})
+	if err != nil {
+		s.logger.Error("Failed to get storage metrics", logging.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	metrics := &StorageMetrics{
+		Current:     storageMetricsSample(resp.Current),
+		History:     make([]*StorageMetricsSample, 0, len(resp.History)),
+		Alerts:      resp.Alerts,
+		CollectedAt: resp.CollectedAt,
+	}
+	for _, sample := range resp.History {
+		metrics.History = append(metrics.History, storageMetricsSample(sample))
+	}
+	if health := resp.Health; health != nil {
+		metrics.Health = &StorageHealth{
+			Status:       health.Status,
+			ResponseTime: time.Duration(health.ResponseTimeMs) * time.Millisecond,
+			Error:        health.ErrorMessage,
+			CheckedAt:    health.CheckedAt,
+		}
+	}
+
+	if len(metrics.Alerts) > 0 {
+		s.logger.Warn("Storage reported alerts", logging.Field{Key: "alerts", Value: metrics.Alerts})
+	}
+
+	s.logger.Info("Storage metrics retrieved successfully", logging.Field{Key: "history_count", Value: len(metrics.History)})
+	return metrics, nil
+}
+
+// Migrate 在内存存储与MongoDB之间迁移数据
+// 该操作会改写服务端持久化数据，必须设置Confirm才会执行
+func (s *StorageManager) Migrate(ctx context.Context, opts *MigrateStorageOptions) (*MigrationResult, error) {
+	if !*s.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if opts == nil {
+		return nil, errors.New(errors.ErrInvalidArgument, "migrate options cannot be nil")
+	}
+	if !opts.Confirm {
+		return nil, errors.New(errors.ErrInvalidArgument, "storage migration requires explicit confirmation")
+	}
+	if !opts.Source.valid() || !opts.Target.valid() {
+		return nil, errors.New(errors.ErrInvalidArgument,
+			fmt.Sprintf("unsupported storage type: %s -> %s", opts.Source, opts.Target))
+	}
+	if opts.Source == opts.Target {
+		return nil, errors.New(errors.ErrInvalidArgument, "source and target storage types must differ")
+	}
+
+	s.logger.Warn("Migrating storage",
+		logging.Field{Key: "source", Value: opts.Source},
+		logging.Field{Key: "target", Value: opts.Target},
+		logging.Field{Key: "force", Value: opts.Force})
+
+	resp, err := s.appService.MigrateStorage(ctx, &dtos.MigrateStorageRequest{
+		SourceType:      string(opts.Source),
+		TargetType:      string(opts.Target),
+		VerifyMigration: opts.Verify,
+		ForceMigration:  opts.Force,
+	})
+	if err != nil {
+		s.logger.Error("Failed to migrate storage", logging.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	result := &MigrationResult{
+		Success:            resp.Success,
+		VerificationPassed: resp.VerificationPassed,
+		CompletedAt:        resp.CompletedAt,
+	}
+	if stats := resp.Stats; stats != nil {
+		result.ConsumerGroupsMigrated = stats.ConsumerGroupsMigrated
+		result.ConsumerOffsetsMigrated = stats.ConsumerOffsetsMigrated
+		result.SmartModulesMigrated = stats.SmartModulesMigrated
+		result.TotalMigrated = stats.TotalMigrated
+		result.Errors = stats.Errors
+	}
+
+	if !resp.Success {
+		errMsg := resp.Error
+		if errMsg == "" {
+			errMsg = "storage migration failed"
+		}
+		s.logger.Error("Storage migration failed", logging.Field{Key: "error", Value: errMsg})
+		return result, errors.New(errors.ErrOperation, errMsg)
+	}
+	if opts.Verify && !resp.VerificationPassed {
+		s.logger.Error("Storage migration verification failed")
+		return result, errors.New(errors.ErrOperation, "storage migration verification failed")
+	}
+
+	s.logger.Info("Storage migrated successfully", logging.Field{Key: "total_migrated", Value: result.TotalMigrated})
+	return result, nil
+}
+
+// valid 检查存储类型是否受支持
+func (t StorageType) valid() bool {
+	return t == StorageMemory || t == StorageMongoDB
+}
+
+// storageMetricsSample 转换存储指标
+func storageMetricsSample(dto *dtos.StorageMetricsDTO) *StorageMetricsSample {
+	if dto == nil {
+		return nil
+	}
+	return &StorageMetricsSample{
+		StorageType:         StorageType(dto.StorageType),
+		ResponseTime:        time.Duration(dto.ResponseTimeMs) * time.Millisecond,
+		OperationsPerSecond: dto.OperationsPerSecond,
+		ErrorRate:           dto.ErrorRate,
+		ConnectionPoolUsage: dto.ConnectionPoolUsage,
+		MemoryUsageMB:       dto.MemoryUsageMB,
+		DiskUsageMB:         dto.DiskUsageMB,
+		Timestamp:           dto.LastUpdated,
+	}
+}
@@ -2,6 +2,7 @@ package fluvio
 
 import (
 	"context"
+	"time"
 
 	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
 	"github.com/iwen-conf/fluvio_grpc_client/application/services"
@@ -20,15 +21,75 @@ type TopicManager struct {
 type CreateTopicOptions struct {
 	Partitions        int32             `json:"partitions,omitempty"`
 	ReplicationFactor int32             `json:"replication_factor,omitempty"`
+	RetentionMs       int64             `json:"retention_ms,omitempty"` // 0表示不限
 	Config            map[string]string `json:"config,omitempty"`
 }
 
 // TopicInfo 主题信息
 type TopicInfo struct {
-	Name              string            `json:"name"`
-	Partitions        int32             `json:"partitions"`
-	ReplicationFactor int32             `json:"replication_factor"`
-	Config            map[string]string `json:"config,omitempty"`
+	Name              string                `json:"name"`
+	Partitions        int32                 `json:"partitions"`
+	ReplicationFactor int32                 `json:"replication_factor"`
+	RetentionMs       int64                 `json:"retention_ms,omitempty"`
+	Config            map[string]string     `json:"config,omitempty"`
+	PartitionDetails  []*TopicPartitionInfo `json:"partition_details,omitempty"`
+}
+
+// TopicPartitionInfo 主题分区详情
+// HighWatermark为下一条待写入消息的偏移量，[LogStartOffset, HighWatermark)为可读范围
+type TopicPartitionInfo struct {
+	Partition      int32   `json:"partition"`
+	LeaderID       int32   `json:"leader_id"`
+	ReplicaIDs     []int32 `json:"replica_ids"`
+	ISRIDs         []int32 `json:"isr_ids"`
+	HighWatermark  int64   `json:"high_watermark"`
+	LogStartOffset int64   `json:"log_start_offset"`
+}
+
+// Retention 获取消息保留时长，0表示未设置
+func (i *TopicInfo) Retention() time.Duration {
+	return time.Duration(i.RetentionMs) * time.Millisecond
+}
+
+// Partition 获取指定分区的详情
+func (i *TopicInfo) Partition(id int32) (*TopicPartitionInfo, bool) {
+	for _, p := range i.PartitionDetails {
+		if p.Partition == id {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// UnderReplicatedPartitions 获取同步副本数少于副本数的分区
+func (i *TopicInfo) UnderReplicatedPartitions() []*TopicPartitionInfo {
+	var result []*TopicPartitionInfo
+	for _, p := range i.PartitionDetails {
+		if p.IsUnderReplicated() {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// IsUnderReplicated 检查分区是否存在未同步的副本
+func (p *TopicPartitionInfo) IsUnderReplicated() bool {
+	return len(p.ISRIDs) < len(p.ReplicaIDs)
+}
+
+// OffsetRange 获取分区当前可读的偏移量范围 [earliest, latest)
+func (p *TopicPartitionInfo) OffsetRange() (earliest, latest int64) {
+	return p.LogStartOffset, p.HighWatermark
+}
+
+// ContainsOffset 检查偏移量是否仍在分区的可读范围内
+func (p *TopicPartitionInfo) ContainsOffset(offset int64) bool {
+	return offset >= p.LogStartOffset && offset < p.HighWatermark
+}
+
+// MessageCount 获取分区当前可读的消息数
+func (p *TopicPartitionInfo) MessageCount() int64 {
+	return p.HighWatermark - p.LogStartOffset
 }
 
 // Create 创建主题
@@ -52,6 +113,7 @@ func (t *TopicManager) Create(ctx context.Context, name string, opts *CreateTopi
 		Name:              name,
 		Partitions:        opts.Partitions,
 		ReplicationFactor: opts.ReplicationFactor,
+		RetentionMs:       opts.RetentionMs,
 		Config:            opts.Config,
 	}
 
@@ -138,9 +200,22 @@ func (t *TopicManager) Info(ctx context.Context, name string) (*TopicInfo, error
 	}
 
 	info := &TopicInfo{
-		Name:       resp.Topic.Name,
-		Partitions: resp.Topic.Partitions,
-		Config:     resp.Topic.Config,
+		Name:              resp.Topic.Name,
+		Partitions:        resp.Topic.Partitions,
+		ReplicationFactor: resp.Topic.ReplicationFactor,
+		RetentionMs:       resp.Topic.RetentionMs,
+		Config:            resp.Topic.Config,
+		PartitionDetails:  make([]*TopicPartitionInfo, len(resp.Topic.PartitionDetails)),
+	}
+	for i, p := range resp.Topic.PartitionDetails {
+		info.PartitionDetails[i] = &TopicPartitionInfo{
+			Partition:      p.PartitionID,
+			LeaderID:       p.LeaderID,
+			ReplicaIDs:     p.ReplicaIDs,
+			ISRIDs:         p.ISRIDs,
+			HighWatermark:  p.HighWatermark,
+			LogStartOffset: p.LowWatermark,
+		}
 	}
 
 	t.logger.Info("Topic info retrieved successfully", logging.Field{Key: "name", Value: name})
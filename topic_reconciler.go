@@ -0,0 +1,355 @@
+package fluvio
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/iwen-conf/fluvio_grpc_client/domain/entities"
+	"github.com/iwen-conf/fluvio_grpc_client/domain/services"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// TopicManifest 主题期望状态清单
+//
+// 清单文件可以是YAML或JSON，格式如下：
+//
+//	topics:
+//	  - name: orders
+//	    partitions: 6
+//	    replication_factor: 3
+//	    retention_ms: 604800000
+//	    config:
+//	      cleanup.policy: delete
+//	      compression.type: zstd
+type TopicManifest struct {
+	Topics []*TopicDefinition `json:"topics" yaml:"topics"`
+}
+
+// TopicDefinition 单个主题的期望状态
+type TopicDefinition struct {
+	Name              string            `json:"name" yaml:"name"`
+	Partitions        int32             `json:"partitions,omitempty" yaml:"partitions,omitempty"`                 // 默认1
+	ReplicationFactor int32             `json:"replication_factor,omitempty" yaml:"replication_factor,omitempty"` // 默认1
+	RetentionMs       int64             `json:"retention_ms,omitempty" yaml:"retention_ms,omitempty"`             // 0表示不限
+	Config            map[string]string `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// Validate 使用TopicService验证主题名称和配置
+func (d *TopicDefinition) Validate() error {
+	topicService := services.NewTopicService()
+	if err := topicService.ValidateTopicName(d.Name); err != nil {
+		return errors.Wrap(errors.ErrValidation, "invalid topic definition", err)
+	}
+
+	topic := entities.NewTopic(d.Name, d.partitions()).
+		WithReplicationFactor(d.ReplicationFactor).
+		WithRetention(d.RetentionMs)
+	if d.Config != nil {
+		topic.WithConfig(d.Config)
+	}
+	if err := topicService.ValidateTopicConfig(topic); err != nil {
+		return errors.Wrap(errors.ErrValidation, fmt.Sprintf("invalid topic definition %s", d.Name), err)
+	}
+	return nil
+}
+
+// partitions 返回分区数，未设置时为1
+func (d *TopicDefinition) partitions() int32 {
+	if d.Partitions == 0 {
+		return 1
+	}
+	return d.Partitions
+}
+
+// replicationFactor 返回复制因子，未设置时为1
+func (d *TopicDefinition) replicationFactor() int32 {
+	if d.ReplicationFactor == 0 {
+		return 1
+	}
+	return d.ReplicationFactor
+}
+
+// createOptions 转换为创建主题选项
+func (d *TopicDefinition) createOptions() *CreateTopicOptions {
+	return &CreateTopicOptions{
+		Partitions:        d.partitions(),
+		ReplicationFactor: d.replicationFactor(),
+		RetentionMs:       d.RetentionMs,
+		Config:            d.Config,
+	}
+}
+
+// LoadTopicManifest 读取主题清单，path为目录时合并目录下所有 .yaml/.yml/.json 文件
+func LoadTopicManifest(path string) (*TopicManifest, error) {
+	files, err := manifestFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &TopicManifest{}
+	seen := make(map[string]string)
+	for _, file := range files {
+		part := &TopicManifest{}
+		if err := decodeManifestFile(file, part); err != nil {
+			return nil, err
+		}
+		for _, def := range part.Topics {
+			if err := def.Validate(); err != nil {
+				return nil, errors.Wrap(errors.ErrValidation, fmt.Sprintf("invalid topic in %s", file), err)
+			}
+			if prev, ok := seen[def.Name]; ok {
+				return nil, errors.New(errors.ErrValidation,
+					fmt.Sprintf("topic %s defined in both %s and %s", def.Name, prev, file))
+			}
+			seen[def.Name] = file
+			manifest.Topics = append(manifest.Topics, def)
+		}
+	}
+	return manifest, nil
+}
+
+// TopicReconcileOptions 主题同步选项
+type TopicReconcileOptions struct {
+	AllowDelete bool     `json:"allow_delete,omitempty"` // 删除清单中不存在的主题
+	Ignore      []string `json:"ignore,omitempty"`       // 不受管理的主题名称模式（path.Match语法），不会被删除
+	DryRun      bool     `json:"dry_run,omitempty"`      // 只计算计划，不执行
+	StopOnFail  bool     `json:"stop_on_fail,omitempty"` // 遇到第一个失败即停止
+}
+
+// SyncDrift 主题存在无法原地应用的差异，需要人工处理
+const SyncDrift SyncAction = "drift"
+
+// TopicChange 单个主题的变更
+// Action为SyncDrift时，Drift描述了无法原地应用的差异（如分区数变化），Apply不会处理
+type TopicChange struct {
+	Action  SyncAction       `json:"action"`
+	Name    string           `json:"name"`
+	Current *TopicInfo       `json:"current,omitempty"`
+	Desired *TopicDefinition `json:"desired,omitempty"`
+	Drift   []string         `json:"drift,omitempty"`
+	Applied bool             `json:"applied"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// TopicPlan 主题同步计划
+type TopicPlan struct {
+	DryRun  bool           `json:"dry_run"`
+	Changes []*TopicChange `json:"changes"`
+}
+
+// HasChanges 检查计划中是否有可执行的变更
+func (p *TopicPlan) HasChanges() bool {
+	for _, change := range p.Changes {
+		if change.Action == SyncCreate || change.Action == SyncDelete {
+			return true
+		}
+	}
+	return false
+}
+
+// Drifted 获取存在漂移的主题
+func (p *TopicPlan) Drifted() []*TopicChange {
+	var result []*TopicChange
+	for _, change := range p.Changes {
+		if change.Action == SyncDrift {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// String 以diff形式输出计划，漂移以 ! 标记
+func (p *TopicPlan) String() string {
+	var b strings.Builder
+	for _, change := range p.Changes {
+		switch change.Action {
+		case SyncCreate:
+			fmt.Fprintf(&b, "+ %s (partitions=%d, replication=%d)\n",
+				change.Name, change.Desired.partitions(), change.Desired.replicationFactor())
+		case SyncDelete:
+			fmt.Fprintf(&b, "- %s\n", change.Name)
+		case SyncDrift:
+			fmt.Fprintf(&b, "! %s (cannot be applied in place)\n", change.Name)
+			for _, drift := range change.Drift {
+				fmt.Fprintf(&b, "    %s\n", drift)
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "no changes\n"
+	}
+	return b.String()
+}
+
+// Plan 比较清单与服务端现有主题，计算同步计划
+func (t *TopicManager) Plan(ctx context.Context, manifest *TopicManifest, opts *TopicReconcileOptions) (*TopicPlan, error) {
+	if !*t.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if manifest == nil {
+		return nil, errors.New(errors.ErrInvalidArgument, "manifest cannot be nil")
+	}
+	if opts == nil {
+		opts = &TopicReconcileOptions{}
+	}
+	for _, pattern := range opts.Ignore {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrap(errors.ErrInvalidArgument, "invalid ignore pattern", err)
+		}
+	}
+
+	names, err := t.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	plan := &TopicPlan{DryRun: opts.DryRun}
+	desired := make(map[string]bool, len(manifest.Topics))
+
+	for _, def := range manifest.Topics {
+		if err := def.Validate(); err != nil {
+			return nil, err
+		}
+		desired[def.Name] = true
+
+		change := &TopicChange{Name: def.Name, Desired: def}
+		if !existing[def.Name] {
+			change.Action = SyncCreate
+			plan.Changes = append(plan.Changes, change)
+			continue
+		}
+
+		current, err := t.Info(ctx, def.Name)
+		if err != nil {
+			return nil, err
+		}
+		change.Current = current
+		change.Drift = diffTopic(current, def)
+		if len(change.Drift) > 0 {
+			change.Action = SyncDrift
+		} else {
+			change.Action = SyncUnchanged
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if opts.AllowDelete {
+		for _, name := range names {
+			if !desired[name] && !matchesAny(opts.Ignore, name) {
+				plan.Changes = append(plan.Changes, &TopicChange{Action: SyncDelete, Name: name})
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool { return plan.Changes[i].Name < plan.Changes[j].Name })
+
+	t.logger.Debug("Topic plan computed",
+		logging.Field{Key: "changes", Value: len(plan.Changes)},
+		logging.Field{Key: "allow_delete", Value: opts.AllowDelete})
+	return plan, nil
+}
+
+// Apply 执行同步计划中的创建和删除，漂移只报告不处理；DryRun计划不会执行任何变更
+func (t *TopicManager) Apply(ctx context.Context, plan *TopicPlan, opts *TopicReconcileOptions) error {
+	if plan == nil {
+		return errors.New(errors.ErrInvalidArgument, "plan cannot be nil")
+	}
+	if opts == nil {
+		opts = &TopicReconcileOptions{}
+	}
+	if plan.DryRun {
+		return nil
+	}
+
+	failed := 0
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Action {
+		case SyncCreate:
+			err = t.Create(ctx, change.Name, change.Desired.createOptions())
+		case SyncDelete:
+			err = t.Delete(ctx, change.Name)
+		default:
+			continue
+		}
+
+		if err != nil {
+			change.Error = err.Error()
+			failed++
+			if opts.StopOnFail {
+				break
+			}
+			continue
+		}
+		change.Applied = true
+	}
+
+	if drifted := plan.Drifted(); len(drifted) > 0 {
+		t.logger.Warn("Topics drifted from manifest", logging.Field{Key: "count", Value: len(drifted)})
+	}
+
+	if failed > 0 {
+		return errors.New(errors.ErrOperation, fmt.Sprintf("%d topic changes failed", failed))
+	}
+
+	t.logger.Info("Topic plan applied", logging.Field{Key: "changes", Value: len(plan.Changes)})
+	return nil
+}
+
+// Reconcile 根据清单计算并执行同步计划
+func (t *TopicManager) Reconcile(ctx context.Context, manifest *TopicManifest, opts *TopicReconcileOptions) (*TopicPlan, error) {
+	plan, err := t.Plan(ctx, manifest, opts)
+	if err != nil {
+		return nil, err
+	}
+	return plan, t.Apply(ctx, plan, opts)
+}
+
+// diffTopic 比较现有主题和期望状态，返回差异描述
+// 配置项只比较清单中声明的键
+func diffTopic(current *TopicInfo, desired *TopicDefinition) []string {
+	var drift []string
+	if current.Partitions != desired.partitions() {
+		drift = append(drift, fmt.Sprintf("partitions: %d -> %d", current.Partitions, desired.partitions()))
+	}
+	if current.ReplicationFactor != desired.replicationFactor() {
+		drift = append(drift, fmt.Sprintf("replication_factor: %d -> %d", current.ReplicationFactor, desired.replicationFactor()))
+	}
+	if current.RetentionMs != desired.RetentionMs {
+		drift = append(drift, fmt.Sprintf("retention_ms: %d -> %d", current.RetentionMs, desired.RetentionMs))
+	}
+
+	keys := make([]string, 0, len(desired.Config))
+	for key := range desired.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		currentValue, ok := current.Config[key]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("config %s: <unset> -> %q", key, desired.Config[key]))
+		} else if currentValue != desired.Config[key] {
+			drift = append(drift, fmt.Sprintf("config %s: %q -> %q", key, currentValue, desired.Config[key]))
+		}
+	}
+	return drift
+}
+
+// matchesAny 检查名称是否匹配任一模式
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
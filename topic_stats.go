@@ -0,0 +1,133 @@
+package fluvio
+
+import (
+	"context"
+	"time"
+
+	"github.com/iwen-conf/fluvio_grpc_client/application/dtos"
+	"github.com/iwen-conf/fluvio_grpc_client/infrastructure/logging"
+	"github.com/iwen-conf/fluvio_grpc_client/pkg/errors"
+)
+
+// TopicStats 主题统计信息
+type TopicStats struct {
+	Topic             string            `json:"topic"`
+	PartitionCount    int32             `json:"partition_count"`
+	ReplicationFactor int32             `json:"replication_factor"`
+	TotalMessageCount int64             `json:"total_message_count"`
+	TotalSizeBytes    int64             `json:"total_size_bytes"`
+	Partitions        []*PartitionStats `json:"partitions,omitempty"` // 仅在includePartitions为true时填充
+	CreatedAt         time.Time         `json:"created_at"`
+	LastUpdated       time.Time         `json:"last_updated"`
+	CollectedAt       time.Time         `json:"collected_at"`
+}
+
+// PartitionStats 分区统计信息
+// LatestOffset为下一条待写入消息的偏移量，[EarliestOffset, LatestOffset)为可读范围
+type PartitionStats struct {
+	Partition      int32     `json:"partition"`
+	MessageCount   int64     `json:"message_count"`
+	TotalSizeBytes int64     `json:"total_size_bytes"`
+	EarliestOffset int64     `json:"earliest_offset"`
+	LatestOffset   int64     `json:"latest_offset"`
+	LastUpdated    time.Time `json:"last_updated"`
+}
+
+// Partition 获取指定分区的统计信息
+func (s *TopicStats) Partition(id int32) (*PartitionStats, bool) {
+	for _, p := range s.Partitions {
+		if p.Partition == id {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Stats 获取主题统计信息
+func (t *TopicManager) Stats(ctx context.Context, name string, includePartitions bool) (*TopicStats, error) {
+	if !*t.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	if name == "" {
+		return nil, errors.New(errors.ErrInvalidArgument, "topic name cannot be empty")
+	}
+
+	t.logger.Debug("Getting topic stats", logging.Field{Key: "name", Value: name})
+
+	stats, err := t.queryStats(ctx, name, includePartitions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range stats {
+		if s.Topic == name {
+			t.logger.Info("Topic stats retrieved successfully", logging.Field{Key: "name", Value: name})
+			return s, nil
+		}
+	}
+
+	return nil, errors.New(errors.ErrNotFound, "topic stats not found: "+name)
+}
+
+// AllStats 获取所有主题的统计信息
+func (t *TopicManager) AllStats(ctx context.Context, includePartitions bool) ([]*TopicStats, error) {
+	if !*t.connected {
+		return nil, errors.New(errors.ErrConnection, "client not connected")
+	}
+
+	t.logger.Debug("Getting stats for all topics")
+
+	stats, err := t.queryStats(ctx, "", includePartitions)
+	if err != nil {
+		return nil, err
+	}
+
+	t.logger.Info("Topic stats retrieved successfully", logging.Field{Key: "count", Value: len(stats)})
+	return stats, nil
+}
+
+// queryStats 调用GetTopicStats并转换结果，topic为空表示所有主题
+func (t *TopicManager) queryStats(ctx context.Context, topic string, includePartitions bool) ([]*TopicStats, error) {
+	resp, err := t.appService.GetTopicStats(ctx, &dtos.TopicStatsRequest{
+		Topic:             topic,
+		IncludePartitions: includePartitions,
+	})
+	if err != nil {
+		t.logger.Error("Failed to get topic stats", logging.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(errors.ErrOperation, resp.Error)
+	}
+
+	result := make([]*TopicStats, len(resp.Topics))
+	for i, s := range resp.Topics {
+		stats := &TopicStats{
+			Topic:             s.Topic,
+			PartitionCount:    s.PartitionCount,
+			ReplicationFactor: s.ReplicationFactor,
+			TotalMessageCount: s.TotalMessageCount,
+			TotalSizeBytes:    s.TotalSizeBytes,
+			CreatedAt:         s.CreatedAt,
+			LastUpdated:       s.LastUpdated,
+			CollectedAt:       resp.CollectedAt,
+		}
+		if includePartitions {
+			stats.Partitions = make([]*PartitionStats, len(s.Partitions))
+			for j, p := range s.Partitions {
+				stats.Partitions[j] = &PartitionStats{
+					Partition:      p.PartitionID,
+					MessageCount:   p.MessageCount,
+					TotalSizeBytes: p.TotalSizeBytes,
+					EarliestOffset: p.LowWatermark,
+					LatestOffset:   p.HighWatermark,
+					LastUpdated:    p.LastUpdated,
+				}
+			}
+		}
+		result[i] = stats
+	}
+	return result, nil
+}